@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/iso6346"
+
+	"github.com/spf13/cobra"
+)
+
+type convertSizeTypeResult struct {
+	Input        string            `json:"input"`
+	SizeTypeCode string            `json:"size-type-code"`
+	ErrorCode    iso6346.ErrorKind `json:"error-code,omitempty"`
+}
+
+func newConvertSizeTypeCmd(stdin io.Reader, writer io.Writer, config *configs.Config, decoders decoders) *cobra.Command {
+	output := dataOutputValue{value: outputCSV}
+
+	convertSizeTypeCmd := &cobra.Command{
+		Use:   "convert-size-type [code]...",
+		Short: "Convert legacy size-type codes to current size-type codes",
+		Long: `Convert size-type codes of ISO 6346:1984 with four digits, e.g. 2210,
+to size-type codes of ISO 6346:1995, e.g. 22G1. The conversion table is
+
+  ` + filepath.Join("$HOME", appDir, "data", "legacy-size-type.json") + `
+
+Current size-type codes are kept. Every code is converted, but the command
+fails if a code is not convertible.`,
+		Example: `icm convert-size-type 2210 4510
+# Convert lines of stdin and output newline delimited JSON
+cat size-types.txt | icm convert-size-type --output ndjson`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Overwrite(cmd.Flags())
+
+			if utf8.RuneCountInString(config.Delimiter()) != 1 {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			reader := stdin
+			if len(args) != 0 {
+				reader = strings.NewReader(strings.Join(args, "\n"))
+			}
+
+			printResult := newConvertSizeTypePrinter(writer, config, output.value)
+
+			var failed int
+			for r, err := range lineRecords(reader, "") {
+				if err != nil {
+					return err
+				}
+				code := strings.ToUpper(strings.ReplaceAll(r.line, " ", ""))
+				if code == "" {
+					continue
+				}
+				result := convertSizeType(decoders, code)
+				result.Input = r.line
+				if result.ErrorCode != "" {
+					failed++
+				}
+				if err := printResult(result); err != nil {
+					return err
+				}
+			}
+			if failed != 0 {
+				return fmt.Errorf("%d size-type codes are not convertible", failed)
+			}
+			return nil
+		},
+	}
+
+	convertSizeTypeCmd.Flags().SortFlags = false
+
+	convertSizeTypeCmd.Flags().Var(&output, configs.FlagNames.Output,
+		fmt.Sprintf("sets output to %s, %s or %s", outputCSV, outputJSON, outputNDJSON))
+	convertSizeTypeCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	convertSizeTypeCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV output")
+
+	return convertSizeTypeCmd
+}
+
+// convertSizeType converts a legacy size-type code to the current size-type code
+// and keeps a current size-type code.
+func convertSizeType(decoders decoders, code string) convertSizeTypeResult {
+	if iso6346.IsLegacySizeTypeCode(code) == nil {
+		current, _, _, _, _, _, ok := decodeLegacySizeType(decoders, code)
+		if !ok {
+			return convertSizeTypeResult{ErrorCode: iso6346.ErrorKindUnknownLegacySizeTypeCode}
+		}
+		return convertSizeTypeResult{SizeTypeCode: current}
+	}
+	if len(code) != 4 {
+		return convertSizeTypeResult{ErrorCode: iso6346.ErrorKindBadLength}
+	}
+	if found, _ := decoders.lengthDecoder.Decode(code[:1]); !found {
+		return convertSizeTypeResult{ErrorCode: iso6346.ErrorKindUnknownLengthCode}
+	}
+	if found, _, _ := decoders.heightWidthDecoder.Decode(code[1:2]); !found {
+		return convertSizeTypeResult{ErrorCode: iso6346.ErrorKindUnknownHeightWidthCode}
+	}
+	if !isTypeOrTypeGroupCode(decoders.typeDecoder, code[2:]) {
+		return convertSizeTypeResult{ErrorCode: iso6346.ErrorKindUnknownTypeCode}
+	}
+	return convertSizeTypeResult{SizeTypeCode: code}
+}
+
+// newConvertSizeTypePrinter returns a function that prints a result in the output format.
+func newConvertSizeTypePrinter(writer io.Writer, config *configs.Config, output string) func(result convertSizeTypeResult) error {
+	switch output {
+	case outputJSON, outputNDJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		if output == outputJSON {
+			encoder.SetIndent("", "  ")
+		}
+		return func(result convertSizeTypeResult) error {
+			return encoder.Encode(result)
+		}
+	default:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Comma, _ = utf8.DecodeRuneInString(config.Delimiter())
+		headerPrinted := config.NoHeader()
+		return func(result convertSizeTypeResult) error {
+			records := [][]string{}
+			if !headerPrinted {
+				records = append(records, []string{"input", "size-type-code", "error-code"})
+				headerPrinted = true
+			}
+			records = append(records, []string{result.Input, result.SizeTypeCode, string(result.ErrorCode)})
+			return csvWriter.WriteAll(records)
+		}
+	}
+}
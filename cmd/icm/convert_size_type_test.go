@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mrclmr/icm/internal/configs"
+)
+
+func Test_convertSizeTypeCmd(t *testing.T) {
+	type flag struct {
+		name  string
+		value string
+	}
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		flags      []flag
+		wantErr    bool
+		wantWriter string
+	}{
+		{
+			"Convert legacy and current size-type codes with csv output",
+			[]string{"2210", "22 g1", "22GP"},
+			"",
+			nil,
+			false,
+			`input;size-type-code;error-code
+2210;22G1;
+22 g1;22G1;
+22GP;22GP;
+`,
+		},
+		{
+			"Convert lines of stdin with ndjson output",
+			nil,
+			"2210\n\n9999\n",
+			[]flag{{"output", "ndjson"}},
+			true,
+			`{"input":"2210","size-type-code":"22G1"}
+{"input":"9999","size-type-code":"","error-code":"unknown-legacy-size-type-code"}
+`,
+		},
+		{
+			"Convert code with bad length without header",
+			[]string{"22G"},
+			"",
+			[]flag{{"no-header", "true"}},
+			true,
+			`22G;;bad-length
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+
+			d := decoders{
+				sizeTypeDecoders: sizeTypeDecoders{
+					&dummyLengthDecoder{},
+					&dummyHeightWidthDecoder{},
+					&dummyTypeDecoder{},
+				},
+				legacySizeTypeDecoder: &dummyLegacySizeTypeDecoder{},
+			}
+
+			cmd := newConvertSizeTypeCmd(strings.NewReader(tt.stdin), writer, config, d)
+			for _, flag := range tt.flags {
+				if err := cmd.Flags().Set(flag.name, flag.value); err != nil {
+					t.Fatalf("Set(%s, %s): %v", flag.name, flag.value, err)
+				}
+			}
+			if got := cmd.RunE(cmd, tt.args); (got != nil) != tt.wantErr {
+				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
@@ -2,12 +2,20 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/mrclmr/icm/internal/configs"
 	"github.com/mrclmr/icm/internal/data"
 	"github.com/mrclmr/icm/internal/http"
+	"github.com/mrclmr/icm/iso6346"
 
 	"github.com/spf13/cobra"
 )
@@ -42,10 +50,65 @@ func (*filePathValue) Type() string {
 	return "string"
 }
 
+type percentValue struct {
+	value int
+}
+
+func (p *percentValue) String() string {
+	return strconv.Itoa(p.value)
+}
+
+func (p *percentValue) Set(value string) error {
+	percent, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("%d is not between 0 and 100", percent)
+	}
+	p.value = percent
+	return nil
+}
+
+func (*percentValue) Type() string {
+	return "int"
+}
+
+const (
+	ownersFormatHTML = "html"
+	ownersFormatCSV  = "csv"
+	ownersFormatJSON = "json"
+)
+
+type ownersFormatValue struct {
+	value string
+}
+
+func (o *ownersFormatValue) String() string {
+	return o.value
+}
+
+func (o *ownersFormatValue) Set(value string) error {
+	switch value {
+	case ownersFormatHTML, ownersFormatCSV, ownersFormatJSON:
+		o.value = value
+		return nil
+	}
+	return fmt.Errorf("%s is not %s, %s or %s", value, ownersFormatHTML, ownersFormatCSV, ownersFormatJSON)
+}
+
+func (*ownersFormatValue) Type() string {
+	return "string"
+}
+
 func newDownloadOwnersCmd(
+	writer io.Writer,
+	config *configs.Config,
 	writeOwnersCSVFunc data.WriteOwnersCSVFunc,
+	readOwnersCSVFunc data.ReadOwnersCSVFunc,
 	timestampUpdater data.TimestampUpdater,
 	ownersGetter http.OwnersGetter,
+	snapshots data.OwnerSnapshots,
 	homeDir string,
 	ownerCSVPath string,
 ) (*cobra.Command, error) {
@@ -53,6 +116,13 @@ func newDownloadOwnersCmd(
 		homeDir:   homeDir,
 		ownerPath: ownerCSVPath,
 	}
+	keepSnapshots := countValue{value: 24}
+	maxShrink := percentValue{value: 10}
+	var diff bool
+	var force bool
+	var fromFile string
+	var fromFormat ownersFormatValue
+	var columns map[string]string
 
 	downloadOwnersCmd := &cobra.Command{
 		Aliases: []string{"update"},
@@ -64,43 +134,339 @@ Following information is available:
   Owner code
   Company
   City
-  Country`,
+  Country
+  Registered equipment category IDs, e.g. UJ
+
+Container numbers with an equipment category ID that is not registered
+for the owner code are invalid. Owners without registered equipment
+category IDs, e.g. in custom-owner.csv, accept every equipment category ID.
+
+Every download is kept as a snapshot in
+
+  ` + filepath.Join("$HOME", appDir, "data", "owner-snapshots") + `
+
+With --diff the added, removed and changed owners compared to the
+overwritten file are printed as CSV. See 'icm owners' for the history
+of an owner and the rollback to a snapshot.
+
+The file is replaced atomically and the replaced file is kept as a backup
+with the extension .bak. The file is not replaced if an owner code is
+invalid or if the count of owners shrinks by more than --max-shrink
+percent, e.g. because of a partially downloaded page. Use --force to
+replace the file even if the count of owners shrinks.
+
+Without network access the owners are imported with --from-file of
+
+  a saved page of ` + ownerURL + `
+  a CSV export with a header
+  a JSON export with an array of objects
+
+The format is detected by the file extension or set with --from-format.
+Columns of CSV exports and keys of JSON exports are mapped with --columns,
+e.g. code=BIC,company=Name. Unmapped columns are named
+
+  ` + strings.Join(ownerColumnNames, ", ") + `
+
+An owner code like ABCU with equipment category ID is split into the owner
+code ABC and the registered equipment category ID U.`,
 		Example: `# Overwrite owner.csv file with newest owners
 icm download-owners
+# Print deregistered owner codes
+icm download-owners --diff | grep '^removed'
 # Create custom-owner.csv to have additional custom mapping of owner codes
 # Use semicolon as a separator. For using double quotes please see existing
 # owner.csv file.
-echo 'AAA;my company;my city;my country' >> $HOME/.icm/data/custom-owner.csv`,
+echo 'AAA;my company;my city;my country' >> $HOME/.icm/data/custom-owner.csv
+# Register only equipment category IDs U and J for a custom owner code
+echo 'AAB;my company;my city;my country;UJ' >> $HOME/.icm/data/custom-owner.csv
+# Import owners of a page that was saved on another computer
+icm download-owners --from-file page.html
+# Import owners of a CSV export with different column names
+icm download-owners --from-file owners.csv --delimiter , --columns code=BIC,company=Name`,
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return overwriteOwnersFile(cmd.Context(), writeOwnersCSVFunc, timestampUpdater, ownersGetter, filePath.Path())
+			config.Overwrite(cmd.Flags())
+
+			if utf8.RuneCountInString(config.Delimiter()) != 1 {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			getter := ownersGetter
+			if fromFile != "" {
+				delimiter, _ := utf8.DecodeRuneInString(config.Delimiter())
+				var err error
+				getter, err = newFileOwnersGetter(fromFile, fromFormat.value, delimiter, columns)
+				if err != nil {
+					return err
+				}
+			}
+
+			previous, owners, err := overwriteOwnersFile(
+				cmd.Context(),
+				writeOwnersCSVFunc,
+				readOwnersCSVFunc,
+				timestampUpdater,
+				getter,
+				filePath.Path(),
+				maxShrink.value,
+				force,
+			)
+			if err != nil {
+				return err
+			}
+
+			if _, err := snapshots.Save(owners, now()); err != nil {
+				return err
+			}
+			if err := snapshots.Prune(keepSnapshots.value); err != nil {
+				return err
+			}
+
+			if !diff {
+				return nil
+			}
+			printChange := newOwnerChangePrinter(writer, config, outputCSV, false)
+			for _, change := range data.DiffOwners(previous, owners) {
+				if err := printChange("", change); err != nil {
+					return err
+				}
+			}
+			return nil
 		},
 	}
 	downloadOwnersCmd.Flags().VarP(&filePath, "output", "o", "output file")
+	downloadOwnersCmd.Flags().BoolVar(&diff, "diff", false,
+		"prints added, removed and changed owners")
+	downloadOwnersCmd.Flags().Var(&keepSnapshots, "keep-snapshots", "count of kept snapshots")
+	downloadOwnersCmd.Flags().Var(&maxShrink, "max-shrink",
+		"maximum percent of owners that may be removed by a download")
+	downloadOwnersCmd.Flags().BoolVar(&force, "force", false,
+		"replaces the file even if owners shrink by more than --max-shrink")
+	downloadOwnersCmd.Flags().StringVar(&fromFile, "from-file", "",
+		"imports owners of a file instead of downloading them")
+	downloadOwnersCmd.Flags().Var(&fromFormat, "from-format",
+		fmt.Sprintf("sets format of --from-file to %s, %s or %s", ownersFormatHTML, ownersFormatCSV, ownersFormatJSON))
+	downloadOwnersCmd.Flags().StringToStringVar(&columns, "columns", nil,
+		"maps owner fields to columns of --from-file, e.g. code=BIC,company=Name")
+	downloadOwnersCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	downloadOwnersCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV input and output")
 
 	err := downloadOwnersCmd.MarkFlagFilename("output")
 	if err != nil {
 		return nil, err
 	}
+	err = downloadOwnersCmd.MarkFlagFilename("from-file", ownersFormatHTML, "htm", ownersFormatCSV, ownersFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = downloadOwnersCmd.RegisterFlagCompletionFunc("from-format", cobra.FixedCompletions(
+		[]string{ownersFormatHTML, ownersFormatCSV, ownersFormatJSON}, cobra.ShellCompDirectiveNoFileComp))
+	if err != nil {
+		return nil, err
+	}
 
 	return downloadOwnersCmd, nil
 }
 
-func overwriteOwnersFile(ctx context.Context, writeOwnersCSV data.WriteOwnersCSVFunc, timestampUpdater data.TimestampUpdater, ownersDownloader http.OwnersGetter, filePath string) error {
-	if err := timestampUpdater.Update(); err != nil {
-		return err
+var ownerColumnNames = []string{
+	http.DefaultOwnerColumns.Code,
+	http.DefaultOwnerColumns.Company,
+	http.DefaultOwnerColumns.City,
+	http.DefaultOwnerColumns.Country,
+	http.DefaultOwnerColumns.EquipCatIDs,
+}
+
+// newFileOwnersGetter returns an owners getter of the file. The format is detected by
+// the file extension if no format is set.
+func newFileOwnersGetter(filePath, format string, delimiter rune, mapping map[string]string) (http.OwnersGetter, error) {
+	if format == "" {
+		switch ext := strings.ToLower(filepath.Ext(filePath)); ext {
+		case ".html", ".htm":
+			format = ownersFormatHTML
+		case ".csv":
+			format = ownersFormatCSV
+		case ".json":
+			format = ownersFormatJSON
+		default:
+			return nil, fmt.Errorf("format of %s is not detected by extension '%s' (use --from-format)", filePath, ext)
+		}
 	}
 
+	columns := http.DefaultOwnerColumns
+	for field, column := range mapping {
+		switch field {
+		case http.DefaultOwnerColumns.Code:
+			columns.Code = column
+		case http.DefaultOwnerColumns.Company:
+			columns.Company = column
+		case http.DefaultOwnerColumns.City:
+			columns.City = column
+		case http.DefaultOwnerColumns.Country:
+			columns.Country = column
+		case http.DefaultOwnerColumns.EquipCatIDs:
+			columns.EquipCatIDs = column
+		default:
+			return nil, fmt.Errorf("column '%s' is not %s", field, strings.Join(ownerColumnNames, ", "))
+		}
+	}
+
+	switch format {
+	case ownersFormatCSV:
+		return http.NewCSVFileOwnersGetter(filePath, delimiter, columns), nil
+	case ownersFormatJSON:
+		return http.NewJSONFileOwnersGetter(filePath, columns), nil
+	default:
+		if len(mapping) != 0 {
+			return nil, fmt.Errorf("columns are not mapped for format %s", ownersFormatHTML)
+		}
+		return http.NewHTMLFileOwnersGetter(filePath), nil
+	}
+}
+
+// overwriteOwnersFile downloads owners and overwrites the file. It returns the owners
+// of the overwritten file and the downloaded owners.
+func overwriteOwnersFile(
+	ctx context.Context,
+	writeOwnersCSV data.WriteOwnersCSVFunc,
+	readOwnersCSV data.ReadOwnersCSVFunc,
+	timestampUpdater data.TimestampUpdater,
+	ownersDownloader http.OwnersGetter,
+	filePath string,
+	maxShrink int,
+	force bool,
+) ([]iso6346.Owner, []iso6346.Owner, error) {
 	owners, err := ownersDownloader.GetOwners(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := replaceOwnersFile(writeOwnersCSV, readOwnersCSV, owners, filePath, maxShrink, force)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := timestampUpdater.Update(); err != nil {
+		return nil, nil, err
+	}
+	return previous, owners, nil
+}
+
+// replaceOwnersFile replaces the owners of the file if all owner codes are valid and
+// the owners pass checkShrink or force is set. It returns the owners of the replaced file.
+func replaceOwnersFile(
+	writeOwnersCSV data.WriteOwnersCSVFunc,
+	readOwnersCSV data.ReadOwnersCSVFunc,
+	owners []iso6346.Owner,
+	filePath string,
+	maxShrink int,
+	force bool,
+) ([]iso6346.Owner, error) {
+	previous, err := readOwnersFile(readOwnersCSV, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalid owner codes are never written because the file could not be read anymore.
+	if err := checkOwnerCodes(owners); err != nil {
+		return nil, fmt.Errorf("%s is not replaced: %w", filePath, err)
+	}
+	if !force {
+		if err := checkShrink(previous, owners, maxShrink); err != nil {
+			return nil, fmt.Errorf("%s is not replaced: %w (use --force to replace it anyway)", filePath, err)
+		}
+	}
+
+	if err := replaceFile(filePath, func(w io.Writer) error {
+		return writeOwnersCSV(owners, w)
+	}); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// checkOwnerCodes returns an error if an owner code is invalid.
+func checkOwnerCodes(owners []iso6346.Owner) error {
+	for _, o := range owners {
+		if err := iso6346.IsOwnerCode(o.Code); err != nil {
+			return fmt.Errorf("owner code is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkShrink returns an error if the count of owners shrinks by more than
+// maxShrink percent compared to the previous owners.
+func checkShrink(previous, owners []iso6346.Owner, maxShrink int) error {
+	if len(previous) == 0 {
+		return nil
+	}
+	shrink := (len(previous) - len(owners)) * 100 / len(previous)
+	if shrink > maxShrink {
+		return fmt.Errorf("owners shrink from %d to %d by %d%% which is more than %d%%",
+			len(previous), len(owners), shrink, maxShrink)
+	}
+	return nil
+}
+
+// replaceFile writes to a temporary file and renames it to the file path, so the file
+// is never partially written. The replaced file is kept with the extension .bak.
+func replaceFile(filePath string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
 	if err != nil {
 		return err
 	}
+	defer func() {
+		// Removing fails after a successful rename.
+		_ = os.Remove(tmp.Name())
+	}()
 
-	file, err := os.Create(filePath)
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	if err := copyFile(filePath, filePath+".bak"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func copyFile(src, dst string) error {
+	b, err := os.ReadFile(src)
 	if err != nil {
 		return err
 	}
+	return os.WriteFile(dst, b, 0o644)
+}
 
-	return writeOwnersCSV(owners, file)
+// readOwnersFile returns the owners of the file or no owners if the file does not exist.
+func readOwnersFile(readOwnersCSV data.ReadOwnersCSVFunc, filePath string) ([]iso6346.Owner, error) {
+	file, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	owners, err := readOwnersCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return owners, nil
 }
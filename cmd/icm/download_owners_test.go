@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/internal/data/file"
+	"github.com/mrclmr/icm/iso6346"
+)
+
+type dummyTimestampUpdater struct {
+	updated bool
+}
+
+func (u *dummyTimestampUpdater) Update() error {
+	u.updated = true
+	return nil
+}
+
+type dummyOwnersGetter struct {
+	owners []iso6346.Owner
+}
+
+func (g dummyOwnersGetter) GetOwners(context.Context) ([]iso6346.Owner, error) {
+	return g.owners, nil
+}
+
+func Test_downloadOwnersCmd(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	}
+	t.Cleanup(func() { now = time.Now })
+
+	downloaded := []iso6346.Owner{
+		{Code: "AAA", Company: "A Company", City: "A City", Country: "A Country", EquipCatIDs: "UJ"},
+		{Code: "CCC", Company: "C Company", City: "C City", Country: "C Country", EquipCatIDs: "U"},
+	}
+	const (
+		previousOne = "AAA;A Company;A City;A Country;U\n"
+		previousTwo = "AAA;A Company;A City;A Country;U\nBBB;B Company;B City;B Country;U\n"
+		replacedOne = "AAA;A Company;A City;A Country;UJ\n"
+		replacedTwo = "AAA;A Company;A City;A Country;UJ\nCCC;C Company;C City;C Country;U\n"
+		snapshot    = "20260102T150405Z"
+	)
+
+	tests := []struct {
+		name          string
+		previousCSV   string
+		owners        []iso6346.Owner
+		args          []string
+		wantErr       bool
+		wantWriter    string
+		wantOwnerCSV  string
+		wantBackupCSV string
+		wantSnapshots []string
+	}{
+		{
+			"Download owners without diff",
+			previousOne,
+			downloaded,
+			nil,
+			false,
+			"",
+			replacedTwo,
+			previousOne,
+			[]string{snapshot},
+		},
+		{
+			"Download owners with diff",
+			previousTwo,
+			downloaded,
+			[]string{"--diff"},
+			false,
+			`change;owner-code;company;city;country;equipment-category-ids;previous-company;previous-city;previous-country;previous-equipment-category-ids
+changed;AAA;A Company;A City;A Country;UJ;A Company;A City;A Country;U
+removed;BBB;;;;;B Company;B City;B Country;U
+added;CCC;C Company;C City;C Country;U;;;;
+`,
+			replacedTwo,
+			previousTwo,
+			[]string{snapshot},
+		},
+		{
+			"Download owners with diff without previous file",
+			"",
+			downloaded,
+			[]string{"--diff", "--no-header"},
+			false,
+			`added;AAA;A Company;A City;A Country;UJ;;;;
+added;CCC;C Company;C City;C Country;U;;;;
+`,
+			replacedTwo,
+			"",
+			[]string{snapshot},
+		},
+		{
+			"Refuse to replace owners that shrink by more than max shrink",
+			previousTwo,
+			downloaded[:1],
+			nil,
+			true,
+			"",
+			previousTwo,
+			"",
+			nil,
+		},
+		{
+			"Replace owners that shrink by not more than max shrink",
+			previousTwo,
+			downloaded[:1],
+			[]string{"--max-shrink", "50"},
+			false,
+			"",
+			replacedOne,
+			previousTwo,
+			[]string{snapshot},
+		},
+		{
+			"Refuse to replace owners with invalid owner code",
+			previousOne,
+			[]iso6346.Owner{{Code: "AA", Company: "A Company"}},
+			nil,
+			true,
+			"",
+			previousOne,
+			"",
+			nil,
+		},
+		{
+			"Refuse to replace owners with invalid owner code with force",
+			previousOne,
+			[]iso6346.Owner{{Code: "AA", Company: "A Company"}},
+			[]string{"--force"},
+			true,
+			"",
+			previousOne,
+			"",
+			nil,
+		},
+		{
+			"Replace owners with force",
+			previousTwo,
+			downloaded[:1],
+			[]string{"--force"},
+			false,
+			"",
+			replacedOne,
+			previousTwo,
+			[]string{snapshot},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			homeDir := t.TempDir()
+			ownerCSVPath := "owner.csv"
+			if tt.previousCSV != "" {
+				_ = os.WriteFile(filepath.Join(homeDir, ownerCSVPath), []byte(tt.previousCSV), 0o644)
+			}
+
+			snapshots, err := file.NewOwnerSnapshots(homeDir)
+			if err != nil {
+				t.Fatalf("NewOwnerSnapshots() error = %v", err)
+			}
+
+			writer := &bytes.Buffer{}
+			timestampUpdater := &dummyTimestampUpdater{}
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+			cmd, err := newDownloadOwnersCmd(writer, config, file.WriteOwnersCSV, file.ReadOwnersCSV,
+				timestampUpdater, dummyOwnersGetter{owners: tt.owners}, snapshots, homeDir, ownerCSVPath)
+			if err != nil {
+				t.Fatalf("newDownloadOwnersCmd() error = %v", err)
+			}
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+			if timestampUpdater.updated == tt.wantErr {
+				t.Errorf("timestamp updated = %v, want %v", timestampUpdater.updated, !tt.wantErr)
+			}
+			b, _ := os.ReadFile(filepath.Join(homeDir, ownerCSVPath))
+			if string(b) != tt.wantOwnerCSV {
+				t.Errorf("owner CSV = %v, want %v", string(b), tt.wantOwnerCSV)
+			}
+			b, _ = os.ReadFile(filepath.Join(homeDir, ownerCSVPath+".bak"))
+			if string(b) != tt.wantBackupCSV {
+				t.Errorf("backup CSV = %v, want %v", string(b), tt.wantBackupCSV)
+			}
+			entries, _ := os.ReadDir(homeDir)
+			for _, entry := range entries {
+				if filepath.Ext(entry.Name()) == ".tmp" {
+					t.Errorf("temporary file %s is not removed", entry.Name())
+				}
+			}
+			names, _ := snapshots.Names()
+			if !slices.Equal(names, tt.wantSnapshots) {
+				t.Errorf("snapshots = %v, want %v", names, tt.wantSnapshots)
+			}
+		})
+	}
+}
+
+func Test_downloadOwnersCmd_fromFile(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	}
+	t.Cleanup(func() { now = time.Now })
+
+	homeDir := t.TempDir()
+	ownerCSVPath := "owner.csv"
+	exportPath := filepath.Join(homeDir, "export.csv")
+	_ = os.WriteFile(exportPath, []byte("BIC,Name,Town\nAAAU,A Company,A City\nAAAJ,A Company,A City\n"), 0o644)
+
+	snapshots, err := file.NewOwnerSnapshots(homeDir)
+	if err != nil {
+		t.Fatalf("NewOwnerSnapshots() error = %v", err)
+	}
+
+	writer := &bytes.Buffer{}
+	config, _ := configs.ReadConfig(configs.DefaultConfig())
+	cmd, err := newDownloadOwnersCmd(writer, config, file.WriteOwnersCSV, file.ReadOwnersCSV,
+		&dummyTimestampUpdater{}, dummyOwnersGetter{}, snapshots, homeDir, ownerCSVPath)
+	if err != nil {
+		t.Fatalf("newDownloadOwnersCmd() error = %v", err)
+	}
+	cmd.SetArgs([]string{"--from-file", exportPath, "--delimiter", ",", "--columns", "code=BIC,company=Name,city=Town"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	b, _ := os.ReadFile(filepath.Join(homeDir, ownerCSVPath))
+	if want := "AAA;A Company;A City;;UJ\n"; string(b) != want {
+		t.Errorf("owner CSV = %v, want %v", string(b), want)
+	}
+}
+
+func Test_newFileOwnersGetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		format   string
+		mapping  map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{"Detect HTML by extension", "page.HTM", "", nil, "*http.HTMLFileOwnersGetter", false},
+		{"Detect CSV by extension", "owners.csv", "", nil, "*http.CSVFileOwnersGetter", false},
+		{"Detect JSON by extension", "owners.json", "", map[string]string{"code": "bic"}, "*http.JSONFileOwnersGetter", false},
+		{"Set format", "owners.txt", ownersFormatCSV, nil, "*http.CSVFileOwnersGetter", false},
+		{"Unknown extension returns error", "owners.txt", "", nil, "", true},
+		{"Unknown owner field returns error", "owners.csv", "", map[string]string{"zip": "Zip"}, "", true},
+		{"Mapping for HTML returns error", "page.html", "", map[string]string{"code": "BIC"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFileOwnersGetter(tt.filePath, tt.format, ',', tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newFileOwnersGetter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if gotType := fmt.Sprintf("%T", got); gotType != tt.want {
+				t.Errorf("newFileOwnersGetter() = %v, want %v", gotType, tt.want)
+			}
+		})
+	}
+}
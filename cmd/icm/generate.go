@@ -6,10 +6,13 @@ import (
 	"math/rand/v2"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mrclmr/icm/internal/configs"
-	"github.com/mrclmr/icm/internal/cont"
 	"github.com/mrclmr/icm/internal/data"
+	"github.com/mrclmr/icm/internal/edifact"
+	"github.com/mrclmr/icm/iso6346"
 
 	"github.com/spf13/cobra"
 )
@@ -47,8 +50,10 @@ func (o *ownerValue) String() string {
 }
 
 func (o *ownerValue) Set(value string) error {
-	if err := cont.IsOwnerCode(value); err != nil {
-		return err
+	if err := iso6346.IsOwnerCode(value); err != nil {
+		if iso6346.IsILUOwnerKey(value) != nil {
+			return err
+		}
 	}
 	o.value = value
 	return nil
@@ -82,13 +87,54 @@ func (*serialNumValue) Type() string {
 	return "int"
 }
 
-func newGenerateCmd(writer, writerErr io.Writer, config *configs.Config, ownerDecoder data.OwnerDecoder, r *rand.Rand) *cobra.Command {
+const (
+	formatText          = "text"
+	formatEDIFACTCODECO = "edifact-codeco"
+	formatEDIFACTCOPRAR = "edifact-coprar"
+)
+
+type formatValue struct {
+	value string
+}
+
+func (f *formatValue) String() string {
+	return f.value
+}
+
+func (f *formatValue) Set(value string) error {
+	switch value {
+	case formatText, formatEDIFACTCODECO, formatEDIFACTCOPRAR:
+		f.value = value
+		return nil
+	}
+	return fmt.Errorf("%s is not \n%s", value, formatsInfo)
+}
+
+func (*formatValue) Type() string {
+	return "string"
+}
+
+const formatsInfo string = `          ` + formatText + ` = container number per line
+` + formatEDIFACTCODECO + ` = UN/EDIFACT interchange with a CODECO gate-in report
+` + formatEDIFACTCOPRAR + ` = UN/EDIFACT interchange with a COPRAR loading order`
+
+// now returns the preparation time of generated EDIFACT interchanges.
+var now = time.Now
+
+func newGenerateCmd(
+	writer, writerErr io.Writer,
+	config *configs.Config,
+	ownerDecoder, iluOwnerDecoder data.OwnerDecoder,
+	r *rand.Rand,
+) *cobra.Command {
 	count := countValue{value: 1}
 	startValue := serialNumValue{}
 	endValue := serialNumValue{}
 	ownerValue := ownerValue{}
 	var excludeCheckDigit10 bool
 	var excludeErrorProneSerialNumbers bool
+	var ilu bool
+	format := formatValue{value: formatText}
 
 	generateCmd := &cobra.Command{
 		Use:   "generate",
@@ -103,9 +149,25 @@ Equipment category ID 'U' is used for every generated container number.
 
 For a custom owner code use the --owner-code flag.
 
+With --ilu intermodal loading unit (ILU) codes according to EN 13044 are
+generated instead. The owner keys specified in
+
+  ` + filepath.Join("$HOME", appDir, "data", iluOwnerCSV) + `
+
+are used. There is no public ILU owner registry, so this file is empty and
+owner keys are registered in
+
+  ` + filepath.Join("$HOME", appDir, "data", customILUOwnerCSV) + `
+
+A custom owner key like ABCA is set with the --owner flag.
+
 For a custom serial number use the --start and --end flags and optionally the --count flag.
 Using only the --count flag generates pseudo random serial numbers.
 
+With --format edifact-codeco or edifact-coprar a UN/EDIFACT interchange is
+generated for testing. Every container number is in an EQD segment with a
+random size-type code of common containers like 22G1, 42G1 or 45R1.
+
 ` + sepHelp,
 		Example: `icm generate
 icm generate --count 10
@@ -117,21 +179,46 @@ icm generate --count 10 --exclude-check-digit-10 --exclude-error-prone-serial-nu
 icm generate --start 100500 --count 10
 icm generate --start 100500 --end 100600
 icm generate --start 100500 --end 100600 --owner ABC
+# Generate ILU codes of swap bodies and semi-trailers
+icm generate --count 10 --ilu
+icm generate --count 10 --ilu --owner ABCA
 # Generate CSV data set
-icm generate --count 1000000 | icm validate`,
+icm generate --count 1000000 | icm validate
+# Generate an EDIFACT CODECO interchange
+icm generate --count 10 --format edifact-codeco > codeco.edi`,
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			config.Overwrite(cmd.Flags())
 
-			builder := cont.NewUniqueGeneratorBuilder(r).
+			builder := iso6346.NewUniqueGeneratorBuilder(r).
 				Count(count.value).
 				ExcludeCheckDigit10(excludeCheckDigit10).
 				ExcludeErrorProneSerialNumbers(excludeErrorProneSerialNumbers)
 
-			if cmd.Flags().Changed("owner") {
+			if ilu && format.value != formatText {
+				return fmt.Errorf("ILU codes cannot be generated with format %s", format.value)
+			}
+
+			switch {
+			case cmd.Flags().Changed("owner") && ilu:
+				if err := iso6346.IsILUOwnerKey(ownerValue.value); err != nil {
+					return err
+				}
+				builder.ILUOwnerKeys([]string{ownerValue.value})
+			case cmd.Flags().Changed("owner"):
+				if err := iso6346.IsOwnerCode(ownerValue.value); err != nil {
+					return err
+				}
 				builder.OwnerCodes([]string{ownerValue.value})
-			} else {
+			case ilu:
+				keys := iluOwnerDecoder.GetAllOwnerCodes()
+				if len(keys) == 0 {
+					return fmt.Errorf("no ILU owner key is registered (add owner keys to %s or use --owner)",
+						filepath.Join("$HOME", appDir, "data", customILUOwnerCSV))
+				}
+				builder.ILUOwnerKeys(keys)
+			default:
 				builder.OwnerCodes(ownerDecoder.GetAllOwnerCodes())
 			}
 
@@ -147,16 +234,24 @@ icm generate --count 1000000 | icm validate`,
 			if err != nil {
 				return err
 			}
+			if format.value != formatText {
+				var numbers []iso6346.Number
+				for generator.Generate() {
+					numbers = append(numbers, generator.ContNum())
+				}
+				if err := generator.Err(); err != nil {
+					return err
+				}
+				interchange := newEDIFACTInterchange(format.value, numbers, r)
+				return edifact.Write(writer, interchange)
+			}
+
+			layout := numberLayout(config)
 			for generator.Generate() {
-				cn := generator.ContNum()
-				_, err := io.WriteString(writer, fmt.Sprintf("%s%s%s%s%06d%s%d\n",
-					cn.OwnerCode, config.SepOE(),
-					string(cn.EquipCatID), config.SepES(),
-					cn.SerialNumber, config.SepSC(),
-					cn.CheckDigit))
+				_, err := io.WriteString(writer, generator.ContNum().Format(layout)+"\n")
 				writeErr(writerErr, err)
 			}
-			return nil
+			return generator.Err()
 		},
 	}
 
@@ -165,7 +260,8 @@ icm generate --count 1000000 | icm validate`,
 	generateCmd.Flags().VarP(&count, "count", "c", "count of container numbers")
 	generateCmd.Flags().VarP(&startValue, "start", "s", "start of serial number range")
 	generateCmd.Flags().VarP(&endValue, "end", "e", "end of serial number range")
-	generateCmd.Flags().Var(&ownerValue, "owner", "custom owner code")
+	generateCmd.Flags().Var(&ownerValue, "owner", "custom owner code or with --ilu custom ILU owner key")
+	generateCmd.Flags().BoolVar(&ilu, "ilu", false, "generate intermodal loading unit (ILU) codes according to EN 13044")
 	generateCmd.Flags().BoolVar(&excludeCheckDigit10, "exclude-check-digit-10", false, "exclude check digit 10")
 	generateCmd.Flags().BoolVar(&excludeErrorProneSerialNumbers, "exclude-transposition-errors", false,
 		"exclude possible transposition errors")
@@ -174,7 +270,16 @@ icm generate --count 1000000 | icm validate`,
 		return nil
 	}
 	generateCmd.Flags().BoolVar(&excludeErrorProneSerialNumbers, "exclude-error-prone-serial-numbers", false,
-		"exclude error-prone serial numbers. For example swapping the second 0 and first 1 of RCB U 001130 0 results in container number RCB U 010130 0 with a valid check digit 0")
+		"exclude error-prone serial numbers of transposition, jump transposition, twin and substitution errors. For example swapping the second 0 and first 1 of RCB U 001130 0 results in container number RCB U 010130 0 with a valid check digit 0")
+
+	generateCmd.Flags().Var(&format, "format",
+		fmt.Sprintf("sets format to %s, %s or %s\n%s\n", formatText, formatEDIFACTCODECO, formatEDIFACTCOPRAR, formatsInfo))
+	err = generateCmd.RegisterFlagCompletionFunc("format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{formatText, formatEDIFACTCODECO, formatEDIFACTCOPRAR}, cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		return nil
+	}
 
 	generateCmd.Flags().String(configs.FlagNames.SepOE, configs.DefaultValues.SepOE,
 		"ABC(x)U1234560  (x) separates owner code and equipment category id")
@@ -185,3 +290,55 @@ icm generate --count 1000000 | icm validate`,
 
 	return generateCmd
 }
+
+// commonSizeTypeCodes are the size-type codes of common containers that are used
+// in generated EDIFACT interchanges.
+var commonSizeTypeCodes = []string{
+	"22G1", "22R1", "22U1", "22P1", "22T6",
+	"42G1", "42R1", "42U1", "42P1",
+	"45G1", "45R1", "L5G1",
+}
+
+// newEDIFACTInterchange returns an interchange with a CODECO or COPRAR message
+// that has an EQD segment with a random common size-type code for every number.
+func newEDIFACTInterchange(format string, numbers []iso6346.Number, r *rand.Rand) edifact.Interchange {
+	prepared := now()
+	controlRef := strconv.Itoa(r.IntN(1_000_000_000))
+
+	// BGM document name code 34 is a gate-in report and 45 a loading order.
+	identifier := []string{"CODECO", "D", "95B", "UN", "ITG14"}
+	documentCode := "34"
+	if format == formatEDIFACTCOPRAR {
+		identifier = []string{"COPRAR", "D", "00B", "UN", "SMDG21"}
+		documentCode = "45"
+	}
+
+	segments := []edifact.Segment{
+		{Tag: "BGM", Elements: [][]string{{documentCode}, {controlRef}, {"9"}}},
+		{Tag: "DTM", Elements: [][]string{{"137", prepared.Format("200601021504"), "203"}}},
+	}
+	for _, n := range numbers {
+		sizeType := commonSizeTypeCodes[r.IntN(len(commonSizeTypeCodes))]
+		// EQD size-type code list 102 is ISO 6346 and full/empty indicator 5 is full.
+		segments = append(segments, edifact.Segment{
+			Tag:      "EQD",
+			Elements: [][]string{{edifact.QualifierContainer}, {n.String()}, {sizeType, "102", "5"}, nil, nil, {"5"}},
+		})
+	}
+	segments = append(segments, edifact.Segment{
+		Tag:      "CNT",
+		Elements: [][]string{{"16", strconv.Itoa(len(numbers))}},
+	})
+
+	return edifact.Interchange{
+		Sender:     strings.ToUpper(appName),
+		Recipient:  "RECIPIENT",
+		Prepared:   prepared,
+		ControlRef: controlRef,
+		Messages: []edifact.Message{{
+			Ref:        "1",
+			Identifier: identifier,
+			Segments:   segments,
+		}},
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"math/rand/v2"
 	"testing"
+	"time"
 
 	"github.com/mrclmr/icm/internal/configs"
 )
 
 func Test_generateCmd(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)
+	}
+	t.Cleanup(func() { now = time.Now })
+
 	type configOverride struct {
 		name  string
 		value string
@@ -43,6 +49,58 @@ func Test_generateCmd(t *testing.T) {
 			`ABC U 601921 5
 `,
 		},
+		{
+			"Generate 1 random ILU code",
+			nil,
+			[]flag{{
+				name:  "ilu",
+				value: "true",
+			}},
+			false,
+			`ABC A 601921 5
+`,
+		},
+		{
+			"Generate 1 random ILU code with custom owner key",
+			nil,
+			[]flag{
+				{name: "ilu", value: "true"},
+				{name: "owner", value: "ABCK"},
+			},
+			false,
+			`ABC K 601921 5
+`,
+		},
+		{
+			"Generate no ILU code with owner code",
+			nil,
+			[]flag{
+				{name: "ilu", value: "true"},
+				{name: "owner", value: "ABC"},
+			},
+			true,
+			"",
+		},
+		{
+			"Generate no container number with ILU owner key",
+			nil,
+			[]flag{{
+				name:  "owner",
+				value: "ABCA",
+			}},
+			true,
+			"",
+		},
+		{
+			"Generate no ILU code with format edifact-codeco",
+			nil,
+			[]flag{
+				{name: "ilu", value: "true"},
+				{name: "format", value: "edifact-codeco"},
+			},
+			true,
+			"",
+		},
 		{
 			"Generate 3 random container number",
 			nil,
@@ -155,6 +213,53 @@ NAR U 000002 0
 			nil,
 			false,
 			`NAR***U+++601921‧‧‧3
+`,
+		},
+		{
+			"Generate EDIFACT CODECO interchange",
+			nil,
+			[]flag{
+				{
+					name:  "count",
+					value: "2",
+				},
+				{
+					name:  "format",
+					value: "edifact-codeco",
+				},
+			},
+			false,
+			`UNA:+.? '
+UNB+UNOA:2+ICM+RECIPIENT+240102:1504+715356444'
+UNH+1+CODECO:D:95B:UN:ITG14'
+BGM+34+715356444+9'
+DTM+137:202401021504:203'
+EQD+CN+NARU6019213+22G1:102:5+++5'
+EQD+CN+RANU7849683+42P1:102:5+++5'
+CNT+16:2'
+UNT+7+1'
+UNZ+1+715356444'
+`,
+		},
+		{
+			"Generate EDIFACT COPRAR interchange",
+			nil,
+			[]flag{
+				{
+					name:  "format",
+					value: "edifact-coprar",
+				},
+			},
+			false,
+			`UNA:+.? '
+UNB+UNOA:2+ICM+RECIPIENT+240102:1504+715356444'
+UNH+1+COPRAR:D:00B:UN:SMDG21'
+BGM+45+715356444+9'
+DTM+137:202401021504:203'
+EQD+CN+NARU6019213+22G1:102:5+++5'
+CNT+16:1'
+UNT+6+1'
+UNZ+1+715356444'
 `,
 		},
 	}
@@ -168,7 +273,8 @@ NAR U 000002 0
 				config.Map[override.name] = override.value
 			}
 
-			cmd := newGenerateCmd(writer, writerErr, config, &dummyOwnerDecodeUpdater{}, rand.New(rand.NewPCG(1, 0)))
+			cmd := newGenerateCmd(writer, writerErr, config, &dummyOwnerDecodeUpdater{}, &dummyILUOwnerDecoder{},
+				rand.New(rand.NewPCG(1, 0)))
 			for _, flag := range tt.flags {
 				_ = cmd.Flags().Set(flag.name, flag.value)
 			}
@@ -181,3 +287,30 @@ NAR U 000002 0
 		})
 	}
 }
+
+type emptyILUOwnerDecoder struct {
+	dummyILUOwnerDecoder
+}
+
+func (emptyILUOwnerDecoder) GetAllOwnerCodes() []string {
+	return nil
+}
+
+func Test_generateCmdWithoutILUOwnerKeys(t *testing.T) {
+	writer := &bytes.Buffer{}
+	config, _ := configs.ReadConfig(configs.DefaultConfig())
+	cmd := newGenerateCmd(writer, &bytes.Buffer{}, config, &dummyOwnerDecodeUpdater{}, &emptyILUOwnerDecoder{},
+		rand.New(rand.NewPCG(1, 0)))
+	_ = cmd.Flags().Set("ilu", "true")
+	if err := cmd.RunE(cmd, nil); err == nil {
+		t.Errorf("got = nil, want error without ILU owner keys")
+	}
+
+	_ = cmd.Flags().Set("owner", "ABCA")
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Errorf("got = %v, want no error with custom ILU owner key", err)
+	}
+	if writer.Len() == 0 {
+		t.Errorf("gotWriter is empty, want ILU code")
+	}
+}
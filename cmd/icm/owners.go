@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/internal/data"
+	"github.com/mrclmr/icm/internal/data/file"
+	"github.com/mrclmr/icm/iso6346"
+
+	"github.com/spf13/cobra"
+)
+
+type ownerRecord struct {
+	Company     string `json:"company"`
+	City        string `json:"city"`
+	Country     string `json:"country"`
+	EquipCatIDs string `json:"equipment-category-ids"`
+}
+
+type ownerSourceResult struct {
+	Source    string       `json:"source"`
+	Winning   bool         `json:"winning"`
+	OwnerCode string       `json:"owner-code"`
+	Owner     *ownerRecord `json:"owner"`
+}
+
+type ownerChangeResult struct {
+	Snapshot  string       `json:"snapshot,omitempty"`
+	Change    string       `json:"change"`
+	OwnerCode string       `json:"owner-code"`
+	Current   *ownerRecord `json:"current"`
+	Previous  *ownerRecord `json:"previous"`
+}
+
+func newOwnersCmd(
+	writer io.Writer,
+	config *configs.Config,
+	ownerDecoder data.OwnerDecoder,
+	writeOwnersCSVFunc data.WriteOwnersCSVFunc,
+	readOwnersCSVFunc data.ReadOwnersCSVFunc,
+	snapshots data.OwnerSnapshots,
+	homeDir, ownerCSVPath string,
+) (*cobra.Command, error) {
+	ownersCmd := &cobra.Command{
+		Use:   "owners",
+		Short: "Inspect owner sources and restore snapshots of downloaded owners",
+		Long: `Inspect owner sources and restore snapshots of downloaded owners.
+
+Every download of owners is kept as a snapshot in
+
+  ` + filepath.Join("$HOME", appDir, "data", "owner-snapshots"),
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+	}
+
+	ownersCmd.AddCommand(newOwnersShowCmd(writer, config, ownerDecoder))
+	ownersCmd.AddCommand(newOwnersHistoryCmd(writer, config, snapshots))
+	ownersCmd.AddCommand(newOwnersSnapshotsCmd(writer, config, snapshots))
+	rollbackCmd, err := newOwnersRollbackCmd(writer, config, writeOwnersCSVFunc, readOwnersCSVFunc, snapshots, homeDir, ownerCSVPath)
+	if err != nil {
+		return nil, err
+	}
+	ownersCmd.AddCommand(rollbackCmd)
+
+	return ownersCmd, nil
+}
+
+func newOwnersShowCmd(writer io.Writer, config *configs.Config, ownerDecoder data.OwnerDecoder) *cobra.Command {
+	output := dataOutputValue{value: outputCSV}
+
+	showCmd := &cobra.Command{
+		Use:   "show CODE",
+		Short: "Show an owner of every owner source",
+		Long: `Show an owner of every owner source that has the owner code from lowest
+to highest priority. The owner of the winning source is used by all commands.
+
+The owner sources are configured with ` + configs.FlagNames.OwnerSources + ` in
+
+  ` + filepath.Join("$HOME", appDir, configs.ConfigNameWithYmlExt),
+		Example: `icm owners show ABC
+# Show the owner as JSON
+icm owners show ABC --output json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Overwrite(cmd.Flags())
+
+			if utf8.RuneCountInString(config.Delimiter()) != 1 {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			code := strings.ToUpper(args[0])
+			if err := iso6346.IsOwnerCode(code); err != nil {
+				return err
+			}
+
+			sourcedOwners := ownerDecoder.DecodeSources(code)
+			if len(sourcedOwners) == 0 {
+				return fmt.Errorf("%s is not in any owner source", code)
+			}
+
+			printResult := newOwnerSourcePrinter(writer, config, output.value)
+			for i, sourcedOwner := range sourcedOwners {
+				if err := printResult(ownerSourceResult{
+					Source:    sourcedOwner.Source,
+					Winning:   i == len(sourcedOwners)-1,
+					OwnerCode: code,
+					Owner:     newOwnerRecord(sourcedOwner.Owner),
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	showCmd.Flags().SortFlags = false
+
+	showCmd.Flags().Var(&output, configs.FlagNames.Output,
+		fmt.Sprintf("sets output to %s, %s or %s", outputCSV, outputJSON, outputNDJSON))
+	showCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	showCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV output")
+
+	return showCmd
+}
+
+func newOwnersHistoryCmd(writer io.Writer, config *configs.Config, snapshots data.OwnerSnapshots) *cobra.Command {
+	output := dataOutputValue{value: outputCSV}
+
+	historyCmd := &cobra.Command{
+		Use:   "history CODE",
+		Short: "Show how an owner changed across snapshots",
+		Long: `Show how an owner changed across snapshots from oldest to newest.
+Only snapshots in which the owner was added, removed or changed are listed.`,
+		Example: `icm owners history ABC
+# Show the history as JSON
+icm owners history ABC --output json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Overwrite(cmd.Flags())
+
+			if utf8.RuneCountInString(config.Delimiter()) != 1 {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			code := strings.ToUpper(args[0])
+			if err := iso6346.IsOwnerCode(code); err != nil {
+				return err
+			}
+
+			names, err := snapshots.Names()
+			if err != nil {
+				return err
+			}
+
+			printChange := newOwnerChangePrinter(writer, config, output.value, true)
+			var previous []iso6346.Owner
+			for _, name := range names {
+				owners, err := snapshots.Read(name)
+				if err != nil {
+					return err
+				}
+				current := ownersWithCode(owners, code)
+				for _, change := range data.DiffOwners(previous, current) {
+					if err := printChange(name, change); err != nil {
+						return err
+					}
+				}
+				previous = current
+			}
+			return nil
+		},
+	}
+
+	historyCmd.Flags().SortFlags = false
+
+	historyCmd.Flags().Var(&output, configs.FlagNames.Output,
+		fmt.Sprintf("sets output to %s, %s or %s", outputCSV, outputJSON, outputNDJSON))
+	historyCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	historyCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV output")
+
+	return historyCmd
+}
+
+func newOwnersSnapshotsCmd(writer io.Writer, config *configs.Config, snapshots data.OwnerSnapshots) *cobra.Command {
+	snapshotsCmd := &cobra.Command{
+		Use:               "snapshots",
+		Short:             "List snapshots of downloaded owners",
+		Long:              `List snapshots of downloaded owners from oldest to newest with the count of owners.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			config.Overwrite(cmd.Flags())
+
+			delimiter, size := utf8.DecodeRuneInString(config.Delimiter())
+			if size == 0 || size != len(config.Delimiter()) {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			names, err := snapshots.Names()
+			if err != nil {
+				return err
+			}
+
+			csvWriter := csv.NewWriter(writer)
+			csvWriter.Comma = delimiter
+			if !config.NoHeader() {
+				if err := csvWriter.Write([]string{"snapshot", "owners"}); err != nil {
+					return err
+				}
+			}
+			for _, name := range names {
+				owners, err := snapshots.Read(name)
+				if err != nil {
+					return err
+				}
+				if err := csvWriter.Write([]string{name, strconv.Itoa(len(owners))}); err != nil {
+					return err
+				}
+			}
+			csvWriter.Flush()
+			return csvWriter.Error()
+		},
+	}
+
+	snapshotsCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	snapshotsCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV output")
+
+	return snapshotsCmd
+}
+
+func newOwnersRollbackCmd(
+	writer io.Writer,
+	config *configs.Config,
+	writeOwnersCSVFunc data.WriteOwnersCSVFunc,
+	readOwnersCSVFunc data.ReadOwnersCSVFunc,
+	snapshots data.OwnerSnapshots,
+	homeDir, ownerCSVPath string,
+) (*cobra.Command, error) {
+	filePath := filePathValue{
+		homeDir:   homeDir,
+		ownerPath: ownerCSVPath,
+	}
+	maxShrink := percentValue{value: 10}
+	var force bool
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback SNAPSHOT",
+		Short: "Restore owners of a snapshot",
+		Long: `Restore owners of a snapshot by overwriting
+
+  ` + filepath.Join("$HOME", ownerCSVPath) + `
+
+or the file of --output, e.g. if the owners were downloaded with
+'icm download-owners --output'.
+
+The file is replaced atomically and the replaced file is kept as a backup
+with the extension .bak. The file is not replaced if an owner code of the
+snapshot is invalid or if the count of owners shrinks by more than
+--max-shrink percent. Use --force to replace the file even if the count
+of owners shrinks.
+
+The restored owners are only used if the file is an owner source.
+The owner sources are configured with ` + configs.FlagNames.OwnerSources + ` in
+
+  ` + filepath.Join("$HOME", appDir, configs.ConfigNameWithYmlExt) + `
+
+The snapshots are listed with 'icm owners snapshots'.`,
+		Example: `icm owners rollback 20260101T120000Z
+# Restore owners of a file that was downloaded with --output
+icm owners rollback 20260101T120000Z --output owners.csv`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			names, _ := snapshots.Names()
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			owners, err := snapshots.Read(args[0])
+			if err != nil {
+				return err
+			}
+			_, err = replaceOwnersFile(writeOwnersCSVFunc, readOwnersCSVFunc, owners, filePath.Path(), maxShrink.value, force)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(writer, "restored owners of snapshot %s\n", args[0]); err != nil {
+				return err
+			}
+			if !isOwnerSource(config, filepath.Join(homeDir, ownerCSVPath), filePath.Path()) {
+				_, err = fmt.Fprintf(writer, "%s is not an owner source, so the restored owners are not used\n", filePath.Path())
+			}
+			return err
+		},
+	}
+	rollbackCmd.Flags().VarP(&filePath, "output", "o", "output file")
+	rollbackCmd.Flags().Var(&maxShrink, "max-shrink",
+		"maximum percent of owners that may be removed by the rollback")
+	rollbackCmd.Flags().BoolVar(&force, "force", false,
+		"replaces the file even if owners shrink by more than --max-shrink")
+
+	if err := rollbackCmd.MarkFlagFilename("output"); err != nil {
+		return nil, err
+	}
+	return rollbackCmd, nil
+}
+
+// isOwnerSource returns true if the owner file in path is read by an owner source of config.
+// Relative owner sources are relative to the directory of the owner file in ownersPath.
+func isOwnerSource(config *configs.Config, ownersPath, path string) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, source := range file.ResolveOwnerSources(ownersPath, config.OwnerSources()) {
+		if source == file.OwnerSourceEmbedded {
+			continue
+		}
+		source, err := filepath.Abs(source)
+		if err != nil {
+			continue
+		}
+		if path == source || filepath.Dir(path) == source && filepath.Ext(path) == ".csv" {
+			return true
+		}
+	}
+	return false
+}
+
+// ownersWithCode returns the owners with the owner code.
+func ownersWithCode(owners []iso6346.Owner, code string) []iso6346.Owner {
+	var filtered []iso6346.Owner
+	for _, o := range owners {
+		if o.Code == code {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered
+}
+
+// newOwnerChangePrinter returns a function that prints an owner change in the output format.
+// The snapshot is only printed if withSnapshot is true.
+func newOwnerChangePrinter(writer io.Writer, config *configs.Config, output string, withSnapshot bool) func(snapshot string, change data.OwnerChange) error {
+	toResult := func(snapshot string, change data.OwnerChange) ownerChangeResult {
+		result := ownerChangeResult{Change: string(change.Kind), OwnerCode: change.Code}
+		if withSnapshot {
+			result.Snapshot = snapshot
+		}
+		if change.Kind != data.OwnerRemoved {
+			result.Current = newOwnerRecord(change.Current)
+		}
+		if change.Kind != data.OwnerAdded {
+			result.Previous = newOwnerRecord(change.Previous)
+		}
+		return result
+	}
+
+	switch output {
+	case outputJSON, outputNDJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		if output == outputJSON {
+			encoder.SetIndent("", "  ")
+		}
+		return func(snapshot string, change data.OwnerChange) error {
+			return encoder.Encode(toResult(snapshot, change))
+		}
+	default:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Comma, _ = utf8.DecodeRuneInString(config.Delimiter())
+		headerPrinted := config.NoHeader()
+		return func(snapshot string, change data.OwnerChange) error {
+			records := [][]string{}
+			if !headerPrinted {
+				header := []string{
+					"change", "owner-code",
+					"company", "city", "country", "equipment-category-ids",
+					"previous-company", "previous-city", "previous-country", "previous-equipment-category-ids",
+				}
+				if withSnapshot {
+					header = append([]string{"snapshot"}, header...)
+				}
+				records = append(records, header)
+				headerPrinted = true
+			}
+			result := toResult(snapshot, change)
+			record := []string{result.Change, result.OwnerCode}
+			record = append(record, result.Current.fields()...)
+			record = append(record, result.Previous.fields()...)
+			if withSnapshot {
+				record = append([]string{result.Snapshot}, record...)
+			}
+			records = append(records, record)
+			return csvWriter.WriteAll(records)
+		}
+	}
+}
+
+// newOwnerSourcePrinter returns a function that prints an owner of an owner source in the output format.
+func newOwnerSourcePrinter(writer io.Writer, config *configs.Config, output string) func(result ownerSourceResult) error {
+	switch output {
+	case outputJSON, outputNDJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		if output == outputJSON {
+			encoder.SetIndent("", "  ")
+		}
+		return func(result ownerSourceResult) error {
+			return encoder.Encode(result)
+		}
+	default:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Comma, _ = utf8.DecodeRuneInString(config.Delimiter())
+		headerPrinted := config.NoHeader()
+		return func(result ownerSourceResult) error {
+			records := [][]string{}
+			if !headerPrinted {
+				records = append(records, []string{
+					"source", "winning", "owner-code",
+					"company", "city", "country", "equipment-category-ids",
+				})
+				headerPrinted = true
+			}
+			record := []string{result.Source, strconv.FormatBool(result.Winning), result.OwnerCode}
+			records = append(records, append(record, result.Owner.fields()...))
+			return csvWriter.WriteAll(records)
+		}
+	}
+}
+
+func newOwnerRecord(o iso6346.Owner) *ownerRecord {
+	return &ownerRecord{
+		Company:     o.Company,
+		City:        o.City,
+		Country:     o.Country,
+		EquipCatIDs: o.EquipCatIDs,
+	}
+}
+
+// fields returns the CSV fields of an owner record or empty fields if there is no record.
+func (r *ownerRecord) fields() []string {
+	if r == nil {
+		return []string{"", "", "", ""}
+	}
+	return []string{r.Company, r.City, r.Country, r.EquipCatIDs}
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/internal/data/file"
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func Test_ownersCmd(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      bool
+		wantWriter   string
+		wantOwnerCSV string
+	}{
+		{
+			"Show owner of every owner source",
+			[]string{"show", "abc"},
+			false,
+			`source;winning;owner-code;company;city;country;equipment-category-ids
+some-source;false;ABC;some-registered-company;some-city;some-country;
+some-custom-source;true;ABC;some-company;some-city;some-country;
+`,
+			"",
+		},
+		{
+			"Show owner of owner source with ndjson output",
+			[]string{"show", "DEF", "--output", "ndjson"},
+			false,
+			`{"source":"some-source","winning":true,"owner-code":"DEF","owner":{"company":"some-u-company","city":"some-city","country":"some-country","equipment-category-ids":"U"}}
+`,
+			"",
+		},
+		{
+			"Show owner that is not in any owner source",
+			[]string{"show", "XYZ"},
+			true,
+			"",
+			"",
+		},
+		{
+			"Show history of owner",
+			[]string{"history", "abc"},
+			false,
+			`snapshot;change;owner-code;company;city;country;equipment-category-ids;previous-company;previous-city;previous-country;previous-equipment-category-ids
+20260101T120000Z;added;ABC;A Company;A City;A Country;U;;;;
+20260301T120000Z;changed;ABC;A New Company;A City;A Country;U;A Company;A City;A Country;U
+20260401T120000Z;removed;ABC;;;;;A New Company;A City;A Country;U
+`,
+			"",
+		},
+		{
+			"Show history of owner with ndjson output",
+			[]string{"history", "ABC", "--output", "ndjson"},
+			false,
+			`{"snapshot":"20260101T120000Z","change":"added","owner-code":"ABC","current":{"company":"A Company","city":"A City","country":"A Country","equipment-category-ids":"U"},"previous":null}
+{"snapshot":"20260301T120000Z","change":"changed","owner-code":"ABC","current":{"company":"A New Company","city":"A City","country":"A Country","equipment-category-ids":"U"},"previous":{"company":"A Company","city":"A City","country":"A Country","equipment-category-ids":"U"}}
+{"snapshot":"20260401T120000Z","change":"removed","owner-code":"ABC","current":null,"previous":{"company":"A New Company","city":"A City","country":"A Country","equipment-category-ids":"U"}}
+`,
+			"",
+		},
+		{
+			"Show history of invalid owner code",
+			[]string{"history", "AB"},
+			true,
+			"",
+			"",
+		},
+		{
+			"List snapshots",
+			[]string{"snapshots"},
+			false,
+			`snapshot;owners
+20260101T120000Z;1
+20260201T120000Z;2
+20260301T120000Z;2
+20260401T120000Z;1
+`,
+			"",
+		},
+		{
+			"Roll back to snapshot",
+			[]string{"rollback", "20260201T120000Z"},
+			false,
+			"restored owners of snapshot 20260201T120000Z\n",
+			"ABC;A Company;A City;A Country;U\nDEF;D Company;D City;D Country;U\n",
+		},
+		{
+			"Roll back to missing snapshot",
+			[]string{"rollback", "20250101T120000Z"},
+			true,
+			"",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			homeDir := t.TempDir()
+			ownerCSVPath := "owner.csv"
+
+			snapshots, err := file.NewOwnerSnapshots(homeDir)
+			if err != nil {
+				t.Fatalf("NewOwnerSnapshots() error = %v", err)
+			}
+			abc := iso6346.Owner{Code: "ABC", Company: "A Company", City: "A City", Country: "A Country", EquipCatIDs: "U"}
+			abcRenamed := iso6346.Owner{Code: "ABC", Company: "A New Company", City: "A City", Country: "A Country", EquipCatIDs: "U"}
+			def := iso6346.Owner{Code: "DEF", Company: "D Company", City: "D City", Country: "D Country", EquipCatIDs: "U"}
+			for i, owners := range [][]iso6346.Owner{{abc}, {abc, def}, {abcRenamed, def}, {def}} {
+				if _, err := snapshots.Save(owners, time.Date(2026, time.Month(i+1), 1, 12, 0, 0, 0, time.UTC)); err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+			}
+
+			writer := &bytes.Buffer{}
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+			cmd, err := newOwnersCmd(writer, config, &dummyOwnerDecodeUpdater{}, file.WriteOwnersCSV, file.ReadOwnersCSV,
+				snapshots, homeDir, ownerCSVPath)
+			if err != nil {
+				t.Fatalf("newOwnersCmd() error = %v", err)
+			}
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+			b, _ := os.ReadFile(filepath.Join(homeDir, ownerCSVPath))
+			if string(b) != tt.wantOwnerCSV {
+				t.Errorf("owner CSV = %v, want %v", string(b), tt.wantOwnerCSV)
+			}
+		})
+	}
+}
+
+func Test_ownersRollbackCmd(t *testing.T) {
+	const (
+		previousCSV = "ABC;A Company;A City;A Country;U\nDEF;D Company;D City;D Country;U\n"
+		snapshotCSV = "ABC;A Company;A City;A Country;U\n"
+	)
+
+	tests := []struct {
+		name          string
+		args          []string
+		wantErr       bool
+		wantWriter    string
+		wantOwnerCSV  string
+		wantBackupCSV string
+	}{
+		{
+			"Refuse to roll back to snapshot with owners that shrink by more than max shrink",
+			nil,
+			true,
+			"",
+			previousCSV,
+			"",
+		},
+		{
+			"Roll back to snapshot with owners that shrink by not more than max shrink",
+			[]string{"--max-shrink", "50"},
+			false,
+			"restored owners of snapshot 20260101T120000Z\n",
+			snapshotCSV,
+			previousCSV,
+		},
+		{
+			"Roll back to snapshot with force",
+			[]string{"--force"},
+			false,
+			"restored owners of snapshot 20260101T120000Z\n",
+			snapshotCSV,
+			previousCSV,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			homeDir := t.TempDir()
+			ownerCSVPath := "owner.csv"
+			_ = os.WriteFile(filepath.Join(homeDir, ownerCSVPath), []byte(previousCSV), 0o644)
+
+			snapshots, err := file.NewOwnerSnapshots(homeDir)
+			if err != nil {
+				t.Fatalf("NewOwnerSnapshots() error = %v", err)
+			}
+			abc := iso6346.Owner{Code: "ABC", Company: "A Company", City: "A City", Country: "A Country", EquipCatIDs: "U"}
+			if _, err := snapshots.Save([]iso6346.Owner{abc}, time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			writer := &bytes.Buffer{}
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+			cmd, err := newOwnersCmd(writer, config, &dummyOwnerDecodeUpdater{}, file.WriteOwnersCSV, file.ReadOwnersCSV,
+				snapshots, homeDir, ownerCSVPath)
+			if err != nil {
+				t.Fatalf("newOwnersCmd() error = %v", err)
+			}
+			cmd.SetArgs(append([]string{"rollback", "20260101T120000Z"}, tt.args...))
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+			b, _ := os.ReadFile(filepath.Join(homeDir, ownerCSVPath))
+			if string(b) != tt.wantOwnerCSV {
+				t.Errorf("owner CSV = %v, want %v", string(b), tt.wantOwnerCSV)
+			}
+			b, _ = os.ReadFile(filepath.Join(homeDir, ownerCSVPath+".bak"))
+			if string(b) != tt.wantBackupCSV {
+				t.Errorf("backup CSV = %v, want %v", string(b), tt.wantBackupCSV)
+			}
+		})
+	}
+}
+
+func Test_ownersRollbackCmdOutput(t *testing.T) {
+	homeDir := t.TempDir()
+	ownerCSVPath := "owner.csv"
+	outputDir := t.TempDir()
+
+	snapshots, err := file.NewOwnerSnapshots(homeDir)
+	if err != nil {
+		t.Fatalf("NewOwnerSnapshots() error = %v", err)
+	}
+	abc := iso6346.Owner{Code: "ABC", Company: "A Company", City: "A City", Country: "A Country", EquipCatIDs: "U"}
+	if _, err := snapshots.Save([]iso6346.Owner{abc}, time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		ownerSources string
+		output       string
+		wantWriter   string
+	}{
+		{
+			"Roll back to file of owner source",
+			outputDir,
+			filepath.Join(outputDir, "owners.csv"),
+			"restored owners of snapshot 20260101T120000Z\n",
+		},
+		{
+			"Roll back to file that is not an owner source",
+			"owner.csv",
+			filepath.Join(outputDir, "owners.csv"),
+			"restored owners of snapshot 20260101T120000Z\n" +
+				filepath.Join(outputDir, "owners.csv") + " is not an owner source, so the restored owners are not used\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			config, err := configs.ReadConfig([]byte("no-header: false\nowner-sources:\n  - " + tt.ownerSources + "\n"))
+			if err != nil {
+				t.Fatalf("ReadConfig() error = %v", err)
+			}
+			cmd, err := newOwnersCmd(writer, config, &dummyOwnerDecodeUpdater{}, file.WriteOwnersCSV, file.ReadOwnersCSV,
+				snapshots, homeDir, ownerCSVPath)
+			if err != nil {
+				t.Fatalf("newOwnersCmd() error = %v", err)
+			}
+			cmd.SetArgs([]string{"rollback", "20260101T120000Z", "--output", tt.output})
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+			b, _ := os.ReadFile(tt.output)
+			if want := "ABC;A Company;A City;A Country;U\n"; string(b) != want {
+				t.Errorf("owner CSV = %v, want %v", string(b), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mrclmr/icm/internal/edifact"
+	"github.com/mrclmr/icm/internal/input"
+	"github.com/mrclmr/icm/internal/x12"
+)
+
+// record is a line to validate with data that is printed before the validation data.
+type record struct {
+	line string
+	data []input.Datum
+}
+
+// lineRecords returns every line of reader as record.
+// If file is set the records have the file name and the line number as data.
+func lineRecords(reader io.Reader, file string) iter.Seq2[record, error] {
+	return func(yield func(record, error) bool) {
+		scanner := bufio.NewScanner(reader)
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			r := record{line: scanner.Text()}
+			if file != "" {
+				r.data = fileData(file, lineNum)
+			}
+			if !yield(r, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(record{}, err)
+		}
+	}
+}
+
+func fileData(file string, lineNum int) []input.Datum {
+	return []input.Datum{
+		input.NewDatum("file").WithValue(file),
+		input.NewDatum("line").WithIntValue(lineNum),
+	}
+}
+
+// columnReader reads a column of CSV files. All files must have the same header.
+// The header must not have a reserved column because the original columns are
+// printed together with the validation columns.
+type columnReader struct {
+	column     string
+	delimiter  rune
+	reserved   []string
+	header     []string
+	headerFile string
+}
+
+func newColumnReader(column string, delimiter rune, reserved []string) *columnReader {
+	return &columnReader{
+		column:    column,
+		delimiter: delimiter,
+		reserved:  reserved,
+	}
+}
+
+// records returns the value of the column of every CSV row as record.
+// The original columns are kept as data. If file is set the records have
+// the file name and the line number as data, too.
+func (cr *columnReader) records(reader io.Reader, file string) iter.Seq2[record, error] {
+	return func(yield func(record, error) bool) {
+		csvReader := csv.NewReader(reader)
+		csvReader.Comma = cr.delimiter
+
+		header, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(record{}, err)
+			return
+		}
+		if cr.header == nil {
+			for _, column := range header {
+				if slices.Contains(cr.reserved, column) {
+					yield(record{}, fmt.Errorf("column %s of %s is also a validation column (rename the column)", column, file))
+					return
+				}
+			}
+			cr.header = header
+			cr.headerFile = file
+		} else if !slices.Equal(cr.header, header) {
+			yield(record{}, fmt.Errorf("header of %s differs from header of %s", file, cr.headerFile))
+			return
+		}
+		columnIdx := slices.Index(header, cr.column)
+		if columnIdx == -1 {
+			yield(record{}, fmt.Errorf("column %s not found in header of %s", cr.column, file))
+			return
+		}
+
+		for {
+			row, err := csvReader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(record{}, err)
+				return
+			}
+			r := record{line: row[columnIdx]}
+			for i, value := range row {
+				r.data = append(r.data, input.NewDatum(header[i]).WithValue(value))
+			}
+			if file != "" {
+				lineNum, _ := csvReader.FieldPos(0)
+				r.data = append(r.data, fileData(file, lineNum)...)
+			}
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
+
+// interchangeRecords returns the records that newRecords returns for the content of reader.
+// If file is set the records have the file name as first data, too.
+func interchangeRecords(reader io.Reader, file string, newRecords func(data string) ([]record, error)) iter.Seq2[record, error] {
+	return func(yield func(record, error) bool) {
+		b, err := io.ReadAll(reader)
+		if err != nil {
+			yield(record{}, err)
+			return
+		}
+		records, err := newRecords(string(b))
+		if err != nil {
+			if file != "" {
+				err = fmt.Errorf("%s: %w", file, err)
+			}
+			yield(record{}, err)
+			return
+		}
+		for _, r := range records {
+			if file != "" {
+				r.data = append([]input.Datum{input.NewDatum("file").WithValue(file)}, r.data...)
+			}
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
+
+// edifactRecords returns the equipment identification and size-type code of every
+// container EQD segment of a UN/EDIFACT interchange as record. The message reference,
+// message type and segment number are kept as data.
+func edifactRecords(data string) ([]record, error) {
+	segments, err := edifact.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	equipments, err := edifact.Equipments(segments)
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	for _, equipment := range equipments {
+		records = append(records, record{
+			line: strings.TrimSpace(equipment.ID + " " + equipment.SizeType),
+			data: []input.Datum{
+				input.NewDatum("message-reference").WithValue(equipment.MessageRef),
+				input.NewDatum("message-type").WithValue(equipment.MessageType),
+				input.NewDatum("segment-number").WithIntValue(equipment.SegmentNum),
+			},
+		})
+	}
+	return records, nil
+}
+
+// x12Records returns the equipment identifier of every N7 segment of an ANSI X12
+// interchange as record. The transaction set, transaction set control number,
+// segment number and equipment type are kept as data.
+func x12Records(data string) ([]record, error) {
+	segments, err := x12.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	equipments, err := x12.Equipments(segments)
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	for _, equipment := range equipments {
+		records = append(records, record{
+			line: equipment.ID,
+			data: []input.Datum{
+				input.NewDatum("transaction-set").WithValue(equipment.TransactionSet),
+				input.NewDatum("control-number").WithValue(equipment.ControlNumber),
+				input.NewDatum("segment-number").WithIntValue(equipment.SegmentNum),
+				input.NewDatum("equipment-type").WithValue(equipment.Type),
+			},
+		})
+	}
+	return records, nil
+}
+
+// fileRecords returns the records of all files in order.
+func fileRecords(files []string, newRecords func(reader io.Reader, file string) iter.Seq2[record, error]) iter.Seq2[record, error] {
+	return func(yield func(record, error) bool) {
+		for _, file := range files {
+			f, err := os.Open(file)
+			if err != nil {
+				yield(record{}, err)
+				return
+			}
+			for r, err := range newRecords(f, file) {
+				if !yield(r, err) {
+					_ = f.Close()
+					return
+				}
+			}
+			_ = f.Close()
+		}
+	}
+}
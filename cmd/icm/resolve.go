@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/internal/data"
+	"github.com/mrclmr/icm/iso6346"
+
+	"github.com/spf13/cobra"
+)
+
+// dataOutputValue is the output value of commands with only machine readable output.
+type dataOutputValue struct {
+	value string
+}
+
+func (o *dataOutputValue) String() string {
+	return o.value
+}
+
+func (o *dataOutputValue) Set(value string) error {
+	switch value {
+	case outputCSV, outputJSON, outputNDJSON:
+		o.value = value
+		return nil
+	}
+	return fmt.Errorf("%s is not %s, %s or %s", value, outputCSV, outputJSON, outputNDJSON)
+}
+
+func (*dataOutputValue) Type() string {
+	return "string"
+}
+
+type resolveResult struct {
+	Input      string             `json:"input"`
+	Candidates []resolveCandidate `json:"candidates"`
+	Error      string             `json:"error,omitempty"`
+}
+
+type resolveCandidate struct {
+	ContainerNumber string  `json:"container-number"`
+	Confidence      float64 `json:"confidence"`
+}
+
+func newResolveCmd(stdin io.Reader, writer io.Writer, config *configs.Config, ownerDecoder data.OwnerDecoder) *cobra.Command {
+	top := countValue{value: 5}
+	output := dataOutputValue{value: outputCSV}
+
+	resolveCmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "Resolve OCR alternatives to valid container numbers",
+		Long: `Resolve container numbers with alternative characters per position,
+e.g. read by an OCR engine, to container numbers with a valid check digit
+and a registered owner code.
+
+Alternatives of a position are written in brackets with an optional
+confidence from 0 to 1. Characters without confidence have confidence 1:
+
+  ABC U 12[3:0.6,8:0.4]456 0
+
+The candidates are ranked by the product of the confidences.
+
+A line that cannot be resolved, e.g. because of invalid alternatives, has
+no candidates and an error. The other lines are resolved anyway.
+
+` + sepHelp,
+		Example: `icm resolve 'A[B:0.9,8:0.1]C U 68[1:0.7,7:0.3]304 [0:0.6,6:0.4]'
+# Resolve lines of stdin and output the best candidate as newline delimited JSON
+cat ocr.txt | icm resolve --top 1 --output ndjson`,
+		Args:              cobra.MaximumNArgs(6),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Overwrite(cmd.Flags())
+
+			if utf8.RuneCountInString(config.Delimiter()) != 1 {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			reader := stdin
+			if len(args) != 0 {
+				reader = strings.NewReader(strings.Join(args, " "))
+			}
+
+			printResult := newResolvePrinter(writer, config, output.value)
+			isRegistered := func(ownerCode string) bool {
+				found, _ := ownerDecoder.Decode(ownerCode)
+				return found
+			}
+			layout := numberLayout(config)
+
+			var resolveErr error
+			for r, err := range lineRecords(reader, "") {
+				if err != nil {
+					return err
+				}
+				result := resolveResult{Input: r.line, Candidates: []resolveCandidate{}}
+				candidates, err := resolveLine(r.line, isRegistered)
+				if err != nil {
+					resolveErr = cmp.Or(resolveErr, fmt.Errorf("%s: %w", r.line, err))
+					result.Error = err.Error()
+				}
+				if len(candidates) > top.value {
+					candidates = candidates[:top.value]
+				}
+				for _, candidate := range candidates {
+					result.Candidates = append(result.Candidates, resolveCandidate{
+						ContainerNumber: candidate.Format(layout),
+						// Rounding hides floating point artifacts of the confidence product.
+						Confidence: math.Round(candidate.Confidence*1e6) / 1e6,
+					})
+				}
+				if err := printResult(result); err != nil {
+					return err
+				}
+			}
+			return resolveErr
+		},
+	}
+
+	resolveCmd.Flags().SortFlags = false
+
+	resolveCmd.Flags().VarP(&top, "top", "n", "count of best candidates per line")
+	resolveCmd.Flags().Var(&output, configs.FlagNames.Output,
+		fmt.Sprintf("sets output to %s, %s or %s", outputCSV, outputJSON, outputNDJSON))
+	resolveCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	resolveCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV output")
+	resolveCmd.Flags().String(configs.FlagNames.SepOE, configs.DefaultValues.SepOE,
+		"ABC(x)U1234560  (x) separates owner code and equipment category id")
+	resolveCmd.Flags().String(configs.FlagNames.SepES, configs.DefaultValues.SepES,
+		"ABCU(x)1234560  (x) separates equipment category id and serial number")
+	resolveCmd.Flags().String(configs.FlagNames.SepSC, configs.DefaultValues.SepSC,
+		"ABCU123456(x)0  (x) separates serial number and check digit")
+
+	return resolveCmd
+}
+
+func resolveLine(line string, isRegistered func(ownerCode string) bool) ([]iso6346.Candidate, error) {
+	positions, err := iso6346.ParseAlternatives(line)
+	if err != nil {
+		return nil, err
+	}
+	return iso6346.Resolve(positions, isRegistered)
+}
+
+// newResolvePrinter returns a function that prints a result in the output format.
+// CSV output has a row per candidate and a row without candidate for results without candidates.
+// The error of a result is in the last column.
+func newResolvePrinter(writer io.Writer, config *configs.Config, output string) func(result resolveResult) error {
+	switch output {
+	case outputJSON, outputNDJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		if output == outputJSON {
+			encoder.SetIndent("", "  ")
+		}
+		return func(result resolveResult) error {
+			return encoder.Encode(result)
+		}
+	default:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Comma, _ = utf8.DecodeRuneInString(config.Delimiter())
+		headerPrinted := config.NoHeader()
+		return func(result resolveResult) error {
+			records := [][]string{}
+			if !headerPrinted {
+				records = append(records, []string{"input", "rank", "container-number", "confidence", "error"})
+				headerPrinted = true
+			}
+			for i, candidate := range result.Candidates {
+				records = append(records, []string{
+					result.Input,
+					strconv.Itoa(i + 1),
+					candidate.ContainerNumber,
+					strconv.FormatFloat(candidate.Confidence, 'f', -1, 64),
+					"",
+				})
+			}
+			if len(result.Candidates) == 0 {
+				records = append(records, []string{result.Input, "", "", "", result.Error})
+			}
+			return csvWriter.WriteAll(records)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mrclmr/icm/internal/configs"
+)
+
+func Test_resolveCmd(t *testing.T) {
+	type flag struct {
+		name  string
+		value string
+	}
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		flags      []flag
+		wantErr    bool
+		wantWriter string
+	}{
+		{
+			"Resolve alternatives with csv output",
+			[]string{"A[B:0.9,8:0.1]C U 68[1:0.7,7:0.3]304 [0:0.6,6:0.4]"},
+			"",
+			nil,
+			false,
+			`input;rank;container-number;confidence;error
+A[B:0.9,8:0.1]C U 68[1:0.7,7:0.3]304 [0:0.6,6:0.4];1;ABC U 681304 0;0.378;
+A[B:0.9,8:0.1]C U 68[1:0.7,7:0.3]304 [0:0.6,6:0.4];2;ABC U 687304 0;0.162;
+`,
+		},
+		{
+			"Resolve lines of stdin with top 1 and ndjson output",
+			nil,
+			"ABC U 68[1:0.3,7:0.7]304 [0:0.5,6:0.5]\nXYZ U 681304 0\n",
+			[]flag{{"top", "1"}, {"output", "ndjson"}},
+			false,
+			`{"input":"ABC U 68[1:0.3,7:0.7]304 [0:0.5,6:0.5]","candidates":[{"container-number":"ABC U 687304 0","confidence":0.35}]}
+{"input":"XYZ U 681304 0","candidates":[]}
+`,
+		},
+		{
+			"Resolve line without candidate with csv output",
+			[]string{"ABC U 681304 1"},
+			"",
+			[]flag{{"no-header", "true"}},
+			false,
+			`ABC U 681304 1;;;;
+`,
+		},
+		{
+			"Resolve invalid alternatives",
+			[]string{"ABC U 68[1:0.3,7:0.7304 0"},
+			"",
+			[]flag{{"no-header", "true"}},
+			true,
+			`ABC U 68[1:0.3,7:0.7304 0;;;;[1:0.3,7:0.7304 0 has no closing bracket
+`,
+		},
+		{
+			"Resolve lines of stdin with invalid line and ndjson output",
+			nil,
+			"ABC U 68130\nABC U 681304 0\n",
+			[]flag{{"output", "ndjson"}},
+			true,
+			`{"input":"ABC U 68130","candidates":[],"error":"9 positions are not 11 positions"}
+{"input":"ABC U 681304 0","candidates":[{"container-number":"ABC U 681304 0","confidence":1}]}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+
+			cmd := newResolveCmd(strings.NewReader(tt.stdin), writer, config, &dummyOwnerDecodeUpdater{})
+			for _, flag := range tt.flags {
+				if err := cmd.Flags().Set(flag.name, flag.value); err != nil {
+					t.Fatalf("Set(%s, %s): %v", flag.name, flag.value, err)
+				}
+			}
+			if got := cmd.RunE(cmd, tt.args); (got != nil) != tt.wantErr {
+				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
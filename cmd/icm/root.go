@@ -21,6 +21,10 @@ type decoders struct {
 	ownerDecodeUpdater data.OwnerDecoder
 	equipCatDecoder    data.EquipCatDecoder
 	sizeTypeDecoders
+	iluOwnerDecoder data.OwnerDecoder
+	countryDecoder  data.CountryDecoder
+
+	legacySizeTypeDecoder data.LegacySizeTypeDecoder
 }
 
 type sizeTypeDecoders struct {
@@ -30,11 +34,13 @@ type sizeTypeDecoders struct {
 }
 
 const (
-	appName        = "icm"
-	appDir         = "." + appName
-	ownerURL       = "https://www.bic-code.org/search/bic-codes/country/all/results/17576"
-	ownerCSV       = "owner.csv"
-	customOwnerCSV = "custom-owner.csv"
+	appName  = "icm"
+	appDir   = "." + appName
+	ownerURL = "https://www.bic-code.org/search/bic-codes/country/all/results/17576"
+	ownerCSV = "owner.csv"
+	// ILU owner keys are registered separately from owner codes of ISO 6346.
+	iluOwnerCSV       = "ilu-owner.csv"
+	customILUOwnerCSV = "custom-ilu-owner.csv"
 )
 
 var sepHelp = `Configuration for separators is generated first time you
@@ -70,7 +76,10 @@ func Execute(version string) {
 	checkErr(stderr, err)
 
 	ownerCSVPath := filepath.Join(appDirDataPath, ownerCSV)
-	ownerDecoder, err := file.NewOwnerDecoder(ownerCSVPath, filepath.Join(customOwnerCSV))
+	ownerDecoder, err := file.NewOwnerDecoder(ownerCSVPath, config.OwnerSources())
+	checkErr(stderr, err)
+
+	iluOwnerDecoder, err := file.NewILUOwnerDecoder(filepath.Join(appDirDataPath, iluOwnerCSV), filepath.Join(appDirDataPath, customILUOwnerCSV))
 	checkErr(stderr, err)
 
 	equipCatDecoder, err := file.NewEquipCatDecoder(appDirDataPath)
@@ -82,12 +91,21 @@ func Execute(version string) {
 	typeDecoder, err := file.NewTypeDecoder(appDirDataPath)
 	checkErr(stderr, err)
 
+	countryDecoder, err := file.NewCountryDecoder(appDirDataPath)
+	checkErr(stderr, err)
+
+	legacySizeTypeDecoder, err := file.NewLegacySizeTypeDecoder(appDirDataPath)
+	checkErr(stderr, err)
+
 	downloader := http.NewOwnersDownloader(ownerURL)
 	checkErr(stderr, err)
 
 	timestampUpdater, err := file.NewTimestampUpdater(appDirDataPath)
 	checkErr(stderr, err)
 
+	ownerSnapshots, err := file.NewOwnerSnapshots(appDirDataPath)
+	checkErr(stderr, err)
+
 	bufWriter := bufio.NewWriter(os.Stdout)
 	rootCmd, err := newRootCmd(
 		version,
@@ -102,10 +120,15 @@ func Execute(version string) {
 				heightWidthDecoder,
 				typeDecoder,
 			},
+			iluOwnerDecoder,
+			countryDecoder,
+			legacySizeTypeDecoder,
 		},
 		file.WriteOwnersCSV,
+		file.ReadOwnersCSV,
 		downloader,
 		timestampUpdater,
+		ownerSnapshots,
 		homeDir,
 		filepath.Join(appDir, "data", ownerCSV),
 	)
@@ -130,8 +153,10 @@ func newRootCmd(
 	config *configs.Config,
 	decoders decoders,
 	ownerCreator data.WriteOwnersCSVFunc,
+	ownerReader data.ReadOwnersCSVFunc,
 	ownersGetter http.OwnersGetter,
 	timestampUpdater data.TimestampUpdater,
+	ownerSnapshots data.OwnerSnapshots,
 	homeDir string,
 	ownerCSVPath string,
 ) (*cobra.Command, error) {
@@ -156,17 +181,26 @@ Visit github.com/mrclmr/icm for more docs, issues, pull requests and feedback.
 
 	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
 
-	rootCmd.AddCommand(newGenerateCmd(writer, writerErr, config, decoders.ownerDecodeUpdater, r))
+	rootCmd.AddCommand(newGenerateCmd(writer, writerErr, config, decoders.ownerDecodeUpdater, decoders.iluOwnerDecoder, r))
 	cmd, err := newValidateCmd(os.Stdin, writer, config, decoders)
 	if err != nil {
 		return nil, err
 	}
 	rootCmd.AddCommand(cmd)
-	downloadOwnersCmd, err := newDownloadOwnersCmd(ownerCreator, timestampUpdater, ownersGetter, homeDir, ownerCSVPath)
+	rootCmd.AddCommand(newResolveCmd(os.Stdin, writer, config, decoders.ownerDecodeUpdater))
+	rootCmd.AddCommand(newScanCmd(os.Stdin, writer, config, decoders.ownerDecodeUpdater))
+	rootCmd.AddCommand(newConvertSizeTypeCmd(os.Stdin, writer, config, decoders))
+	rootCmd.AddCommand(newServeCmd(writerErr, config, decoders, r))
+	downloadOwnersCmd, err := newDownloadOwnersCmd(writer, config, ownerCreator, ownerReader, timestampUpdater, ownersGetter, ownerSnapshots, homeDir, ownerCSVPath)
 	if err != nil {
 		return nil, err
 	}
 	rootCmd.AddCommand(downloadOwnersCmd)
+	ownersCmd, err := newOwnersCmd(writer, config, decoders.ownerDecodeUpdater, ownerCreator, ownerReader, ownerSnapshots, homeDir, ownerCSVPath)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd.AddCommand(ownersCmd)
 	rootCmd.AddCommand(newDocCmd(rootCmd))
 
 	return rootCmd, nil
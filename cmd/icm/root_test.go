@@ -1,7 +1,8 @@
 package cmd
 
 import (
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/internal/data"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 type dummyOwnerDecodeUpdater struct {
@@ -11,11 +12,20 @@ type dummyOwnerDecodeUpdater struct {
 
 type dummyOwnerDecoder struct{}
 
-func (dummyOwnerDecoder) Decode(code string) (bool, cont.Owner) {
+func (dummyOwnerDecoder) Decode(code string) (bool, iso6346.Owner) {
+	if code == "DEF" {
+		return true, iso6346.Owner{
+			Code:        "DEF",
+			Company:     "some-u-company",
+			City:        "some-city",
+			Country:     "some-country",
+			EquipCatIDs: "U",
+		}
+	}
 	if code != "ABC" {
-		return false, cont.Owner{}
+		return false, iso6346.Owner{}
 	}
-	return true, cont.Owner{
+	return true, iso6346.Owner{
 		Code:    "ABC",
 		Company: "some-company",
 		City:    "some-city",
@@ -23,20 +33,80 @@ func (dummyOwnerDecoder) Decode(code string) (bool, cont.Owner) {
 	}
 }
 
+func (d dummyOwnerDecoder) DecodeSources(code string) []data.SourcedOwner {
+	found, owner := d.Decode(code)
+	if !found {
+		return nil
+	}
+	if code != "ABC" {
+		return []data.SourcedOwner{{Source: "some-source", Owner: owner}}
+	}
+	registered := owner
+	registered.Company = "some-registered-company"
+	return []data.SourcedOwner{
+		{Source: "some-source", Owner: registered},
+		{Source: "some-custom-source", Owner: owner},
+	}
+}
+
 type dummyOwnerUpdater struct{}
 
 func (dummyOwnerUpdater) GetAllOwnerCodes() []string {
 	return []string{"NAR", "RAN"}
 }
 
-func (dummyOwnerUpdater) Update([]cont.Owner) error {
+func (dummyOwnerUpdater) Update([]iso6346.Owner) error {
 	panic("implement me")
 }
 
+type dummyILUOwnerDecoder struct{}
+
+func (dummyILUOwnerDecoder) Decode(code string) (bool, iso6346.Owner) {
+	if code != "ABCA" {
+		return false, iso6346.Owner{}
+	}
+	return true, iso6346.Owner{
+		Code:    "ABCA",
+		Company: "some-ilu-company",
+		City:    "some-city",
+		Country: "some-country",
+	}
+}
+
+func (d dummyILUOwnerDecoder) DecodeSources(code string) []data.SourcedOwner {
+	found, owner := d.Decode(code)
+	if !found {
+		return nil
+	}
+	return []data.SourcedOwner{{Source: "some-ilu-source", Owner: owner}}
+}
+
+func (dummyILUOwnerDecoder) GetAllOwnerCodes() []string {
+	return []string{"ABCA", "NARA"}
+}
+
+type dummyCountryDecoder struct{}
+
+func (dummyCountryDecoder) Decode(code string) (bool, iso6346.Country) {
+	if code != "DE" {
+		return false, iso6346.Country{}
+	}
+	return true, iso6346.Country{Code: "DE", Name: "some-legacy-country"}
+}
+
+type dummyLegacySizeTypeDecoder struct{}
+
+func (dummyLegacySizeTypeDecoder) Decode(code string) (bool, string) {
+	if code != "2210" {
+		return false, ""
+	}
+	return true, "22G1"
+}
+
 type dummyEquipCatDecoder struct{}
 
-func (dummyEquipCatDecoder) Decode(ID string) (bool, cont.EquipCat) {
-	return true, cont.EquipCat{
+func (dummyEquipCatDecoder) Decode(ID string) (bool, iso6346.EquipCat) {
+	return true, iso6346.EquipCat{
 		Value: ID,
 		Info:  "some-equip-cat-ID",
 	}
@@ -48,18 +118,32 @@ func (dummyEquipCatDecoder) AllCatIDs() []string {
 
 type dummyLengthDecoder struct{}
 
-func (dummyLengthDecoder) Decode(string) (bool, cont.Length) {
+func (dummyLengthDecoder) Decode(string) (bool, iso6346.Length) {
 	return true, "some-length"
 }
 
 type dummyHeightWidthDecoder struct{}
 
-func (dummyHeightWidthDecoder) Decode(string) (bool, cont.Height, cont.Width) {
+func (dummyHeightWidthDecoder) Decode(string) (bool, iso6346.Height, iso6346.Width) {
 	return true, "some-height", "some-width"
 }
 
 type dummyTypeDecoder struct{}
 
-func (dummyTypeDecoder) Decode(string) (bool, cont.TypeInfo, cont.GroupInfo) {
+func (dummyTypeDecoder) Decode(code string) (bool, iso6346.TypeInfo, iso6346.GroupInfo) {
+	if code == "GP" {
+		return false, "", ""
+	}
 	return true, "some-type", "some-group"
 }
+
+func (dummyTypeDecoder) DecodeTypeGroup(code string) (bool, iso6346.TypeGroupInfo, iso6346.GroupInfo) {
+	if code != "GP" {
+		return false, "", ""
+	}
+	return true, "some-type-group", "some-group"
+}
+
+func (dummyTypeDecoder) TypeGroupCode(string) (bool, string) {
+	return true, "GP"
+}
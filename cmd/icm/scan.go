@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/internal/data"
+	"github.com/mrclmr/icm/iso6346"
+
+	"github.com/spf13/cobra"
+)
+
+type scanResult struct {
+	File            string `json:"file,omitempty"`
+	Line            int    `json:"line"`
+	Column          int    `json:"column"`
+	ContainerNumber string `json:"container-number"`
+	Valid           bool   `json:"valid"`
+	ErrorCode       string `json:"error-code,omitempty"`
+}
+
+func newScanCmd(stdin io.Reader, writer io.Writer, config *configs.Config, ownerDecoder data.OwnerDecoder) *cobra.Command {
+	output := dataOutputValue{value: outputCSV}
+
+	scanCmd := &cobra.Command{
+		Use:   "scan [file]...",
+		Short: "Find and validate container numbers in text",
+		Long: `Find container numbers anywhere in text like emails, log files or
+documents converted to text and validate their owner codes and check digits.
+
+Owner code and equipment category ID are found in upper and lower case. A
+space, tab, dot, slash or hyphen is allowed as separator:
+
+  ABCU1234560  ABC U 123456 0  ABCU 123456-0  abcu1234560
+
+Files are scanned in order. Without files stdin is scanned. For every
+container number the file, line, column (starting at 1), the container
+number and its validity are printed. A container number is invalid if the
+owner code is not registered, the equipment category ID is not registered
+for the owner or the check digit is wrong. The error codes are separated
+by ", ".
+
+` + sepHelp,
+		Example: `icm scan email.txt
+# Scan stdin and output only invalid container numbers with jq
+cat *.log | icm scan --output ndjson | jq 'select(.valid | not)'`,
+		RunE: func(cmd *cobra.Command, files []string) error {
+			config.Overwrite(cmd.Flags())
+
+			if utf8.RuneCountInString(config.Delimiter()) != 1 {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
+			}
+
+			printResult := newScanPrinter(writer, config, output.value)
+			layout := numberLayout(config)
+
+			if len(files) == 0 {
+				return scan(stdin, "", ownerDecoder, layout, printResult)
+			}
+			for _, file := range files {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				err = scan(f, file, ownerDecoder, layout, printResult)
+				_ = f.Close()
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	scanCmd.Flags().SortFlags = false
+
+	scanCmd.Flags().Var(&output, configs.FlagNames.Output,
+		fmt.Sprintf("sets output to %s, %s or %s", outputCSV, outputJSON, outputNDJSON))
+	scanCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
+		"omits header of CSV output")
+	scanCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV output")
+	scanCmd.Flags().String(configs.FlagNames.SepOE, configs.DefaultValues.SepOE,
+		"ABC(x)U1234560  (x) separates owner code and equipment category id")
+	scanCmd.Flags().String(configs.FlagNames.SepES, configs.DefaultValues.SepES,
+		"ABCU(x)1234560  (x) separates equipment category id and serial number")
+	scanCmd.Flags().String(configs.FlagNames.SepSC, configs.DefaultValues.SepSC,
+		"ABCU123456(x)0  (x) separates serial number and check digit")
+
+	return scanCmd
+}
+
+// scan prints every container number in the lines of reader.
+func scan(reader io.Reader, file string, ownerDecoder data.OwnerDecoder, layout iso6346.Layout, printResult func(scanResult) error) error {
+	// A bufio.Reader has no maximum line length like a bufio.Scanner.
+	bufReader := bufio.NewReader(reader)
+	lineNum := 0
+	for {
+		line, err := bufReader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if line == "" {
+			return nil
+		}
+		lineNum++
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		for _, match := range iso6346.FindNumbers(line) {
+			errorCodes := scanErrorCodes(ownerDecoder, match)
+			result := scanResult{
+				File:            file,
+				Line:            lineNum,
+				Column:          utf8.RuneCountInString(line[:match.Offset]) + 1,
+				ContainerNumber: match.Format(layout),
+				Valid:           len(errorCodes) == 0,
+				ErrorCode:       strings.Join(errorCodes, ", "),
+			}
+			if err := printResult(result); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// scanErrorCodes returns the error codes of a match whose owner code or equipment
+// category ID is not registered or whose check digit is invalid.
+func scanErrorCodes(ownerDecoder data.OwnerDecoder, match iso6346.NumberMatch) []string {
+	var errorCodes []string
+	found, owner := ownerDecoder.Decode(match.OwnerCode)
+	switch {
+	case !found:
+		errorCodes = append(errorCodes, string(iso6346.ErrorKindOwnerNotRegistered))
+	case !owner.IsEquipCatIDRegistered(string(match.EquipCatID)):
+		errorCodes = append(errorCodes, string(iso6346.ErrorKindEquipCatIDNotRegistered))
+	}
+	var validateErr *iso6346.ValidateError
+	if errors.As(match.Err, &validateErr) {
+		errorCodes = append(errorCodes, string(validateErr.Kind))
+	}
+	return errorCodes
+}
+
+// newScanPrinter returns a function that prints a result in the output format.
+func newScanPrinter(writer io.Writer, config *configs.Config, output string) func(result scanResult) error {
+	switch output {
+	case outputJSON, outputNDJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		if output == outputJSON {
+			encoder.SetIndent("", "  ")
+		}
+		return func(result scanResult) error {
+			return encoder.Encode(result)
+		}
+	default:
+		csvWriter := csv.NewWriter(writer)
+		csvWriter.Comma, _ = utf8.DecodeRuneInString(config.Delimiter())
+		headerPrinted := config.NoHeader()
+		return func(result scanResult) error {
+			records := [][]string{}
+			if !headerPrinted {
+				records = append(records, []string{"file", "line", "column", "container-number", "valid", "error-code"})
+				headerPrinted = true
+			}
+			records = append(records, []string{
+				result.File,
+				strconv.Itoa(result.Line),
+				strconv.Itoa(result.Column),
+				result.ContainerNumber,
+				strconv.FormatBool(result.Valid),
+				result.ErrorCode,
+			})
+			return csvWriter.WriteAll(records)
+		}
+	}
+}
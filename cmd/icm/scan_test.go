@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrclmr/icm/internal/configs"
+)
+
+func Test_scanCmd(t *testing.T) {
+	dir := t.TempDir()
+	email := filepath.Join(dir, "email.txt")
+	if err := os.WriteFile(email, []byte("Hello,\nplease check ABCU6813040 and ABC U 681304-1.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type flag struct {
+		name  string
+		value string
+	}
+	tests := []struct {
+		name       string
+		files      []string
+		stdin      string
+		flags      []flag
+		wantErr    bool
+		wantWriter string
+	}{
+		{
+			"Scan file with csv output",
+			[]string{email},
+			"",
+			nil,
+			false,
+			`file;line;column;container-number;valid;error-code
+` + email + `;2;14;ABC U 681304 0;true;
+` + email + `;2;30;ABC U 681304 1;false;check-digit-mismatch
+`,
+		},
+		{
+			"Scan stdin with ndjson output",
+			nil,
+			"Ünïcödé ABC-U-681304-0\n",
+			[]flag{{"output", "ndjson"}},
+			false,
+			`{"line":1,"column":9,"container-number":"ABC U 681304 0","valid":true}
+`,
+		},
+		{
+			"Scan stdin with lower case and not registered owners",
+			nil,
+			"abc u 681304 0, XYZU6813040, defj6813033 and DEFU6813041\n",
+			nil,
+			false,
+			`file;line;column;container-number;valid;error-code
+;1;1;ABC U 681304 0;true;
+;1;17;XYZ U 681304 0;false;owner-not-registered
+;1;30;DEF J 681303 3;false;equipment-category-id-not-registered, check-digit-mismatch
+;1;46;DEF U 681304 1;false;check-digit-mismatch
+`,
+		},
+		{
+			"Scan stdin with line longer than 64 KB",
+			nil,
+			strings.Repeat("x", 100000) + " ABCU6813040\r\nABCU6813040",
+			[]flag{{"no-header", "true"}},
+			false,
+			`;1;100002;ABC U 681304 0;true;
+;2;1;ABC U 681304 0;true;
+`,
+		},
+		{
+			"Scan file that does not exist",
+			[]string{filepath.Join(dir, "unknown.txt")},
+			"",
+			nil,
+			true,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+
+			cmd := newScanCmd(strings.NewReader(tt.stdin), writer, config, &dummyOwnerDecodeUpdater{})
+			for _, flag := range tt.flags {
+				if err := cmd.Flags().Set(flag.name, flag.value); err != nil {
+					t.Fatalf("Set(%s, %s): %v", flag.name, flag.value, err)
+				}
+			}
+			if got := cmd.RunE(cmd, tt.files); (got != nil) != tt.wantErr {
+				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrclmr/icm/internal/configs"
+	"github.com/mrclmr/icm/internal/input"
+	"github.com/mrclmr/icm/iso6346"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed openapi.json
+var openAPI []byte
+
+const (
+	maxGenerateCount = 10000
+	maxBatchSize     = 10000
+	// maxBatchBodySize limits the request body of a batch before it is decoded.
+	maxBatchBodySize = 1 << 20
+)
+
+func newServeCmd(writerErr io.Writer, config *configs.Config, decoders decoders, r *rand.Rand) *cobra.Command {
+	var addr string
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve validate, generate and owner lookup over HTTP",
+		Long: `Starts an HTTP server with JSON endpoints for validation, generation
+and owner lookup. The fields of the validation results are the same as
+the columns of the CSV output of the validate command.
+
+The OpenAPI document is served at /openapi.json.
+
+` + sepHelp,
+		Example: `icm serve
+icm serve --addr :8080
+curl 'localhost:8080/validate?value=ABC%20U%20123456%200'
+curl -X POST localhost:8080/validate -d '{"values": ["ABC U 123456 0", "20G1"]}'
+curl 'localhost:8080/generate?count=10'
+curl localhost:8080/owners/ABC`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			config.Overwrite(cmd.Flags())
+
+			server := &http.Server{
+				Addr:              addr,
+				Handler:           newServeMux(config, decoders, r),
+				ReadHeaderTimeout: 10 * time.Second,
+				WriteTimeout:      30 * time.Second,
+				IdleTimeout:       2 * time.Minute,
+			}
+			_, _ = fmt.Fprintf(writerErr, "%s: listening on %s\n", appName, addr)
+			return server.ListenAndServe()
+		},
+	}
+
+	serveCmd.Flags().SortFlags = false
+
+	serveCmd.Flags().StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+	serveCmd.Flags().String(configs.FlagNames.SepOE, configs.DefaultValues.SepOE,
+		"ABC(x)U1234560  (x) separates owner code and equipment category id")
+	serveCmd.Flags().String(configs.FlagNames.SepES, configs.DefaultValues.SepES,
+		"ABCU(x)1234560  (x) separates equipment category id and serial number")
+	serveCmd.Flags().String(configs.FlagNames.SepSC, configs.DefaultValues.SepSC,
+		"ABCU123456(x)0  (x) separates serial number and check digit")
+
+	return serveCmd
+}
+
+type server struct {
+	config   *configs.Config
+	decoders decoders
+	rMu      sync.Mutex
+	r        *rand.Rand
+}
+
+func newServeMux(config *configs.Config, decoders decoders, r *rand.Rand) *http.ServeMux {
+	s := &server{
+		config:   config,
+		decoders: decoders,
+		r:        r,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /validate", s.validate)
+	mux.HandleFunc("POST /validate", s.validateBatch)
+	mux.HandleFunc("GET /generate", s.generate)
+	mux.HandleFunc("GET /owners/{code}", s.owner)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPI)
+	})
+	return mux
+}
+
+func (s *server) patterns(value string) (patterns, error) {
+	pValue := newPatternValue(s.config, s.decoders)
+	if value == "" {
+		value = auto
+	}
+	if err := pValue.Set(value); err != nil {
+		return nil, fmt.Errorf("%s is not a valid pattern", value)
+	}
+	return pValue.getPatterns(pValue.value), nil
+}
+
+// validateValue returns the validation result of value like the JSON output of the validate command.
+func validateValue(value string, patterns patterns) (json.RawMessage, error) {
+	pattern := input.Match(value, patterns)
+	inputs, _ := input.Validate(value, pattern.NewInputs)
+	b := &bytes.Buffer{}
+	err := newJSONPrinter(b, "").Print(inputs, input.NewDatum("pattern").WithValue(pattern.Name))
+	return bytes.TrimSpace(b.Bytes()), err
+}
+
+func (s *server) validate(w http.ResponseWriter, req *http.Request) {
+	patterns, err := s.patterns(req.URL.Query().Get("pattern"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	result, err := validateValue(req.URL.Query().Get("value"), patterns)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+type validateBatchRequest struct {
+	Pattern string   `json:"pattern"`
+	Values  []string `json:"values"`
+}
+
+type validateBatchResponse struct {
+	Results []json.RawMessage `json:"results"`
+}
+
+func (s *server) validateBatch(w http.ResponseWriter, req *http.Request) {
+	var batch validateBatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBatchBodySize)).Decode(&batch); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge,
+				fmt.Errorf("request body exceeds maximum of %d bytes", maxBytesErr.Limit))
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if len(batch.Values) > maxBatchSize {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("%d values exceed maximum of %d", len(batch.Values), maxBatchSize))
+		return
+	}
+	patterns, err := s.patterns(batch.Pattern)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	response := validateBatchResponse{Results: []json.RawMessage{}}
+	for _, value := range batch.Values {
+		result, err := validateValue(value, patterns)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		response.Results = append(response.Results, result)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+type generateResponse struct {
+	ContainerNumbers []string `json:"container-numbers"`
+}
+
+func (s *server) generate(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	count := countValue{value: 1}
+	startValue := serialNumValue{}
+	endValue := serialNumValue{}
+	ownerValue := ownerValue{}
+	for name, value := range map[string]interface{ Set(string) error }{
+		"count": &count,
+		"start": &startValue,
+		"end":   &endValue,
+		"owner": &ownerValue,
+	} {
+		if !query.Has(name) {
+			continue
+		}
+		if err := value.Set(query.Get(name)); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+	}
+	if count.value > maxGenerateCount {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("count: %d exceeds maximum of %d", count.value, maxGenerateCount))
+		return
+	}
+	excludeCheckDigit10, err := parseBoolQuery(query.Get("exclude-check-digit-10"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("exclude-check-digit-10: %w", err))
+		return
+	}
+	excludeErrorProne, err := parseBoolQuery(query.Get("exclude-error-prone-serial-numbers"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("exclude-error-prone-serial-numbers: %w", err))
+		return
+	}
+	// ownerValue also accepts ILU owner keys, but only container numbers are generated.
+	if query.Has("owner") {
+		if err := iso6346.IsOwnerCode(ownerValue.value); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("owner: %w", err))
+			return
+		}
+	}
+
+	s.rMu.Lock()
+	defer s.rMu.Unlock()
+
+	builder := iso6346.NewUniqueGeneratorBuilder(s.r).
+		Count(count.value).
+		ExcludeCheckDigit10(excludeCheckDigit10).
+		ExcludeErrorProneSerialNumbers(excludeErrorProne)
+
+	if query.Has("owner") {
+		builder.OwnerCodes([]string{ownerValue.value})
+	} else {
+		builder.OwnerCodes(s.decoders.ownerDecodeUpdater.GetAllOwnerCodes())
+	}
+	if query.Has("start") {
+		builder.Start(startValue.value)
+	}
+	if query.Has("end") {
+		builder.End(endValue.value)
+	}
+
+	generator, err := builder.Build()
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	layout := numberLayout(s.config)
+	response := generateResponse{ContainerNumbers: []string{}}
+	for generator.Generate() {
+		response.ContainerNumbers = append(response.ContainerNumbers, generator.ContNum().Format(layout))
+	}
+	if err := generator.Err(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func parseBoolQuery(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+type ownerResponse struct {
+	Code        string `json:"code"`
+	Company     string `json:"company"`
+	City        string `json:"city"`
+	Country     string `json:"country"`
+	Source      string `json:"source"`
+	EquipCatIDs string `json:"equipment-category-ids"`
+}
+
+func (s *server) owner(w http.ResponseWriter, req *http.Request) {
+	code := strings.ToUpper(req.PathValue("code"))
+	if err := iso6346.IsOwnerCode(code); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	found, owner := s.decoders.ownerDecodeUpdater.Decode(code)
+	if !found {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf("%s is not registered", code))
+		return
+	}
+	writeJSON(w, http.StatusOK, ownerResponse{
+		Code:        owner.Code,
+		Company:     owner.Company,
+		City:        owner.City,
+		Country:     owner.Country,
+		Source:      ownerSource(s.decoders.ownerDecodeUpdater, code),
+		EquipCatIDs: owner.EquipCatIDs,
+	})
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(v)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mrclmr/icm/internal/configs"
+)
+
+func Test_serveMux(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			"Validate single value",
+			http.MethodGet,
+			"/validate?value=abc%20u%20123123%201",
+			"",
+			http.StatusOK,
+			`{"pattern":"container-number","owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"123123",` +
+				`"check-digit":1,"calculated-check-digit":7,"valid-check-digit":false,` +
+				`"possible-transposition-error":[],"error-prone-serial-numbers":[],"check-digit-10-collision":[],"suggestions":["ABC U 123123 7","ABC U 223123 1","ABC U 183123 1","ABC U 126123 1","ABC U 123823 1"],"valid":false,` +
+				`"errors":[{"kind":"check-digit-mismatch","field":"check-digit","offset":10,"expected":"7"}]}
+`,
+		},
+		{
+			"Validate with invalid pattern",
+			http.MethodGet,
+			"/validate?value=abc&pattern=unknown",
+			"",
+			http.StatusBadRequest,
+			`{"error":"unknown is not a valid pattern"}
+`,
+		},
+		{
+			"Validate batch",
+			http.MethodPost,
+			"/validate",
+			`{"pattern":"owner","values":["abc","xyz"]}`,
+			http.StatusOK,
+			`{"results":[` +
+				`{"pattern":"owner","owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},"valid":true,"errors":[]},` +
+				`{"pattern":"owner","owner":{"code":null,"company":null,"city":null,"country":null,"source":null},"valid":false,` +
+				`"errors":[{"kind":"owner-not-registered","field":"owner-code","offset":0}]}]}
+`,
+		},
+		{
+			"Validate batch with invalid body",
+			http.MethodPost,
+			"/validate",
+			`["abc"]`,
+			http.StatusBadRequest,
+			`{"error":"invalid request body: json: cannot unmarshal array into Go value of type cmd.validateBatchRequest"}
+`,
+		},
+		{
+			"Validate batch with too large body",
+			http.MethodPost,
+			"/validate",
+			`{"values":["` + strings.Repeat("a", maxBatchBodySize) + `"]}`,
+			http.StatusRequestEntityTooLarge,
+			`{"error":"request body exceeds maximum of 1048576 bytes"}
+`,
+		},
+		{
+			"Generate container numbers",
+			http.MethodGet,
+			"/generate?owner=ABC&start=100500&count=3",
+			"",
+			http.StatusOK,
+			`{"container-numbers":["ABC U 100500 8","ABC U 100501 3","ABC U 100502 9"]}
+`,
+		},
+		{
+			"Generate with invalid count",
+			http.MethodGet,
+			"/generate?count=0",
+			"",
+			http.StatusBadRequest,
+			`{"error":"count: 0 is not greater than 0"}
+`,
+		},
+		{
+			"Generate with ILU owner key",
+			http.MethodGet,
+			"/generate?owner=ABCA&count=2",
+			"",
+			http.StatusBadRequest,
+			`{"error":"owner: ABCA is not 3 letters long"}
+`,
+		},
+		{
+			"Look up owner",
+			http.MethodGet,
+			"/owners/ABC",
+			"",
+			http.StatusOK,
+			`{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source","equipment-category-ids":""}
+`,
+		},
+		{
+			"Look up owner with lower case code and registered equipment category IDs",
+			http.MethodGet,
+			"/owners/def",
+			"",
+			http.StatusOK,
+			`{"code":"DEF","company":"some-u-company","city":"some-city","country":"some-country","source":"some-source","equipment-category-ids":"U"}
+`,
+		},
+		{
+			"Look up unregistered owner",
+			http.MethodGet,
+			"/owners/XYZ",
+			"",
+			http.StatusNotFound,
+			`{"error":"XYZ is not registered"}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+			d := decoders{
+				ownerDecodeUpdater: &dummyOwnerDecodeUpdater{},
+				equipCatDecoder:    &dummyEquipCatDecoder{},
+				sizeTypeDecoders: sizeTypeDecoders{
+					&dummyLengthDecoder{},
+					&dummyHeightWidthDecoder{},
+					&dummyTypeDecoder{},
+				},
+				countryDecoder: &dummyCountryDecoder{},
+
+				legacySizeTypeDecoder: &dummyLegacySizeTypeDecoder{},
+			}
+			mux := newServeMux(config, d, rand.New(rand.NewPCG(1, 2)))
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if gotBody := rec.Body.String(); gotBody != tt.wantBody {
+				t.Errorf("body = %v, want %v", gotBody, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_serveMuxOpenAPI(t *testing.T) {
+	config, _ := configs.ReadConfig(configs.DefaultConfig())
+	mux := newServeMux(config, decoders{}, rand.New(rand.NewPCG(1, 2)))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	b, _ := io.ReadAll(rec.Body)
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if doc["openapi"] == nil {
+		t.Errorf("openapi.json has no openapi version")
+	}
+}
@@ -3,9 +3,13 @@ package cmd
 import (
 	"bufio"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -13,9 +17,9 @@ import (
 	"unicode/utf8"
 
 	"github.com/mrclmr/icm/internal/configs"
-	"github.com/mrclmr/icm/internal/cont"
 	"github.com/mrclmr/icm/internal/data"
 	"github.com/mrclmr/icm/internal/input"
+	"github.com/mrclmr/icm/iso6346"
 
 	"github.com/logrusorgru/aurora/v4"
 	"github.com/mattn/go-isatty"
@@ -30,31 +34,51 @@ func init() {
 
 type validateError struct {
 	message string
+	cause   *iso6346.ValidateError
 }
 
-func newValidateError(message string) error {
-	return &validateError{message: message}
+func newValidateError(kind iso6346.ErrorKind, field iso6346.Field, expected string, message string) error {
+	return &validateError{
+		message: message,
+		cause:   iso6346.NewFieldError(kind, field, expected, ""),
+	}
 }
 
 func (e *validateError) Error() string {
 	return e.message
 }
 
+func (e *validateError) Unwrap() error {
+	return e.cause
+}
+
 const (
 	auto                   = "auto"
 	containerNumber        = "container-number"
 	owner                  = "owner"
 	ownerEquipmentCategory = "owner-equipment-category"
 	sizeType               = "size-type"
+	ilu                    = "ilu"
+	legacySizeType         = "legacy-size-type"
 )
 
-const patternsInfo string = `                    ` + auto + ` = matches automatically a pattern
+// These patterns are only matched by pattern auto.
+const (
+	containerNumberSizeType        = "container-number-size-type"
+	containerNumberCountry         = "container-number-country"
+	containerNumberCountrySizeType = "container-number-country-size-type"
+	containerNumberLegacySizeType  = "container-number-legacy-size-type"
+)
+
+const patternsInfo string = `                    ` + auto + ` = matches automatically a pattern per line
         ` + containerNumber + ` = matches a container number
                    ` + owner + ` = matches a three letter owner code
 ` + ownerEquipmentCategory + ` = matches a three letter owner code with equipment category ID
-               ` + sizeType + ` = matches length, width+height and type code`
+               ` + sizeType + ` = matches length, width+height and type code
+                     ` + ilu + ` = matches an intermodal loading unit code (EN 13044)
+        ` + legacySizeType + ` = matches a four digit size-type code of ISO 6346:1984`
 
-type patterns = [][]func() input.Input
+type patterns = []input.Pattern
 
 type patternValue struct {
 	config   *configs.Config
@@ -75,7 +99,7 @@ func (p *patternValue) String() string {
 
 func (p *patternValue) Set(value string) error {
 	switch value {
-	case auto, containerNumber, owner, ownerEquipmentCategory, sizeType:
+	case auto, containerNumber, owner, ownerEquipmentCategory, sizeType, ilu, legacySizeType:
 		p.value = value
 		return nil
 	default:
@@ -97,7 +121,11 @@ func (p *patternValue) getPatterns(value string) patterns {
 	case ownerEquipmentCategory:
 		return newOwnerEquipCatPattern(p.decoders)
 	case sizeType:
-		return newSizeTypePattern(p.decoders)
+		return newSizeTypePattern(p.config, p.decoders)
+	case ilu:
+		return newILUPattern(p.config, p.decoders)
+	case legacySizeType:
+		return newLegacySizeTypePattern(p.config, p.decoders)
 	case auto:
 		fallthrough
 	default:
@@ -106,9 +134,11 @@ func (p *patternValue) getPatterns(value string) patterns {
 }
 
 const (
-	outputAuto  = "auto"
-	outputFancy = "fancy"
-	outputCSV   = "csv"
+	outputAuto   = "auto"
+	outputFancy  = "fancy"
+	outputCSV    = "csv"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
 )
 
 type outputValue struct {
@@ -128,7 +158,7 @@ func (o *outputValue) String() string {
 
 func (o *outputValue) Set(value string) error {
 	switch value {
-	case outputAuto, outputFancy, outputCSV:
+	case outputAuto, outputFancy, outputCSV, outputJSON, outputNDJSON:
 		o.value = value
 		return nil
 	}
@@ -139,32 +169,76 @@ func (o *outputValue) Type() string {
 	return "string"
 }
 
-const outputsInfo string = ` ` + outputAuto + ` = for a single line '` + outputFancy +
+const outputsInfo string = `  ` + outputAuto + ` = for a single line '` + outputFancy +
 	`' and for multiple lines '` + outputCSV + `' output 
-  ` + outputCSV + ` = machine readable CSV output
-` + outputFancy + ` = human readable fancy output`
+   ` + outputCSV + ` = machine readable CSV output
+ ` + outputFancy + ` = human readable fancy output
+  ` + outputJSON + ` = machine readable indented JSON object per line
+` + outputNDJSON + ` = machine readable JSON object per line (newline delimited JSON)`
 
-func (o *outputValue) getPrinter(value string, writer io.Writer, isSingleLine bool) input.Printer {
+func (o *outputValue) getPrinter(value string, writer io.Writer, isSingleLine bool, patterns patterns) input.Printer {
 	switch value {
 	case outputFancy:
 		return newFancyPrinter(writer, o.config)
 	case outputCSV:
-		return newCSVPrinter(writer, o.config)
+		return newCSVPrinter(writer, o.config, patterns)
+	case outputJSON:
+		return newJSONPrinter(writer, "  ")
+	case outputNDJSON:
+		return newJSONPrinter(writer, "")
 	case outputAuto:
 		fallthrough
 	default:
 		if isSingleLine {
 			return newFancyPrinter(writer, o.config)
 		}
-		return newCSVPrinter(writer, o.config)
+		return newCSVPrinter(writer, o.config, patterns)
+	}
+}
+
+const (
+	inputFormatLines   = "lines"
+	inputFormatEDIFACT = "edifact"
+	inputFormatX12     = "x12"
+)
+
+type inputFormatValue struct {
+	value string
+}
+
+func (i *inputFormatValue) String() string {
+	return i.value
+}
+
+func (i *inputFormatValue) Set(value string) error {
+	switch value {
+	case inputFormatLines, inputFormatEDIFACT, inputFormatX12:
+		i.value = value
+		return nil
 	}
+	return fmt.Errorf("%s is not \n%s", value, inputFormatsInfo)
 }
 
+func (*inputFormatValue) Type() string {
+	return "string"
+}
+
+const inputFormatsInfo string = `  ` + inputFormatLines + ` = every line is validated
+` + inputFormatEDIFACT + ` = equipment identification and size-type code of every container EQD
+          segment of UN/EDIFACT interchanges like BAPLIE, CODECO or COPRAR are validated
+    ` + inputFormatX12 + ` = equipment initial and number of every N7 segment of ANSI X12
+          interchanges like 322, 315 or 404 are validated as ` + containerNumber
+
 func newValidateCmd(stdin io.Reader, writer io.Writer, config *configs.Config, decoders decoders) (*cobra.Command, error) {
 	pValue := newPatternValue(config, decoders)
 
 	oValue := newOutputValue(config)
 
+	var inputFiles []string
+	var column string
+	var ocrCorrect bool
+	inputFormat := inputFormatValue{value: inputFormatLines}
+
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate intermodal container markings",
@@ -176,6 +250,61 @@ For multi line input CSV output is used. For example this is useful to scan
 data sets for error-prone serial numbers. It is also possible to generate
 CSV data sets of random container numbers.
 
+Files can be validated with --input-file. With --column a column of CSV files
+is validated and the original columns are kept in the output. The original
+columns must not have the name of a validation column like company or file.
+
+Pattern auto also matches container numbers of markings before ISO 6346:1995
+with a two letter country code between check digit and size and type code,
+e.g. ABC U 123456 0 DE 22G1. The country codes are specified in
+
+  ` + filepath.Join("$HOME", appDir, "data", "country.json") + `
+
+Type group codes like GP, RE or TN are accepted instead of detailed type codes
+like G1, e.g. 22GP or 45RE. The type group code of a detailed type code is
+added as column type-group-code. The type groups are specified in
+
+  ` + filepath.Join("$HOME", appDir, "data", "type-group.json") + `
+
+Size-type codes of ISO 6346:1984 with four digits, e.g. 2210, are converted
+to current size-type codes, e.g. 22G1, with the conversion table
+
+  ` + filepath.Join("$HOME", appDir, "data", "legacy-size-type.json") + `
+
+With --normalize-size-type the current size-type code of current and legacy
+size-type codes is added as column size-type-code.
+
+With --input-format edifact the EQD segments of containers (qualifier CN)
+of UN/EDIFACT interchanges are validated. The message reference, message
+type and segment number of every EQD segment are kept in the output.
+
+With --input-format x12 the N7 segments of ANSI X12 interchanges are
+validated with pattern container-number. The transaction set, its control
+number, the segment number and the equipment type are kept in the output.
+
+With --pattern ilu intermodal loading unit (ILU) codes of swap bodies and
+semi-trailers according to EN 13044 are validated. The owner key ends with
+A, B, C, D or K and must be registered in
+
+  ` + filepath.Join("$HOME", appDir, "data", customILUOwnerCSV) + `
+
+There is no public ILU owner registry, so the shipped ILU owner file
+
+  ` + filepath.Join("$HOME", appDir, "data", iluOwnerCSV) + `
+
+is empty.
+
+With --ocr-correct characters commonly confused by OCR (O/0, Q/0, I/1, B/8,
+S/5, Z/2) are replaced in a container number according to the position:
+letters in owner code and equipment category ID, digits in serial number
+and check digit. The correction is only used if the check digit is then
+valid. Every replaced character is listed in column ocr-corrections.
+
+If the check digit does not match, likely intended container numbers are
+listed in column suggestions. For a registered owner code corrections of
+serial number and check digit are suggested. For an unregistered owner code
+only registered owner codes that differ in one letter are suggested.
+
 ` + sepHelp,
 		Example: `icm validate ABC
 # Validate with pattern 'container-number' instead of pattern 'auto'
@@ -185,46 +314,107 @@ icm validate ABC U
 icm validate --sep-owner-equip '' --sep-serial-check '-' ABC U 123456 0
 # Validate a type
 icm validate 20G1
+# Validate a type group code of a booking
+icm validate 45RE
 # Validate a container number with a type
 icm validate ABC U 123456 0 20G1
+# Validate a container number with a legacy country code
+icm validate ABC U 123456 0 DE 20G1
+# Validate a container number with a legacy size-type code
+icm validate ABC U 123456 0 2210
 # Validate a random container number
 icm generate | icm validate
 icm generate --count 10 | icm validate
 icm generate --count 10 | icm validate --output fancy
 # Generate CSV data set
 icm generate --count 1000000 | icm validate
-# Validate a container number with 6 (!) error-prone serial numbers combinations
+# Validate a column of CSV files and append the validation columns
+icm validate --input-file a.csv --input-file b.csv --column container_no --delimiter ','
+# Validate the equipment of an EDIFACT CODECO message
+icm validate --input-format edifact --input-file codeco.edi
+# Validate an ILU code of a swap body
+icm validate --pattern ilu ABC A 123456 6
+# Validate a container number read by OCR
+icm validate --ocr-correct A8C U 68I3O4 O
+# Validate and filter invalid lines with jq
+icm generate --count 10 | icm validate --output ndjson | jq 'select(.valid | not)'
+# Validate a container number with 12 (!) error-prone serial numbers
 icm validate APL U 689473 0`,
-		Args:              cobra.MaximumNArgs(6),
+		Args:              cobra.MaximumNArgs(7),
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config.Overwrite(cmd.Flags())
 
-			var reader io.Reader
-			if len(args) != 0 {
-				reader = strings.NewReader(strings.Join(args, " "))
-			} else {
-				reader = stdin
+			delimiter, size := utf8.DecodeRuneInString(config.Delimiter())
+			if size == 0 || size != len(config.Delimiter()) {
+				return fmt.Errorf("delimiter '%s' is not 1 character", config.Delimiter())
 			}
 
-			bufReader := bufio.NewReader(reader)
-			peek, _ := bufReader.Peek(bufReader.Size())
-			singleLine := isSingleLine(string(peek))
-
-			printer := oValue.getPrinter(config.Output(), writer, singleLine)
+			if column != "" && inputFormat.value != inputFormatLines {
+				return fmt.Errorf("column cannot be used with input format %s", inputFormat.value)
+			}
 
 			patterns := pValue.getPatterns(config.Pattern())
+			if inputFormat.value == inputFormatX12 {
+				patterns = newContNumPattern(config, decoders)
+			}
+
+			colReader := newColumnReader(column, delimiter, reservedColumns(patterns))
+			newRecords := func(reader io.Reader, file string) iter.Seq2[record, error] {
+				switch {
+				case inputFormat.value == inputFormatEDIFACT:
+					return interchangeRecords(reader, file, edifactRecords)
+				case inputFormat.value == inputFormatX12:
+					return interchangeRecords(reader, file, x12Records)
+				case column != "":
+					return colReader.records(reader, file)
+				}
+				return lineRecords(reader, file)
+			}
 
-			newInputs := input.Match(strings.Split(string(peek), "\n")[0], patterns)
+			var records iter.Seq2[record, error]
+			singleLine := false
+			if len(inputFiles) != 0 {
+				if len(args) != 0 {
+					return errors.New("arguments and input files cannot be used together")
+				}
+				records = fileRecords(inputFiles, newRecords)
+			} else {
+				var reader io.Reader
+				if len(args) != 0 {
+					reader = strings.NewReader(strings.Join(args, " "))
+				} else {
+					reader = stdin
+				}
 
-			scanner := bufio.NewScanner(bufReader)
+				bufReader := bufio.NewReader(reader)
+				peek, _ := bufReader.Peek(bufReader.Size())
+				singleLine = column == "" && inputFormat.value == inputFormatLines && isSingleLine(string(peek))
+				records = newRecords(bufReader, "")
+			}
+
+			printer := oValue.getPrinter(config.Output(), writer, singleLine, patterns)
 
 			var inputErr error
 			var inputs []input.Input
 
-			for scanner.Scan() {
-				inputs, inputErr = input.Validate(scanner.Text(), newInputs)
-				err := printer.Print(inputs)
+			for r, err := range records {
+				if err != nil {
+					return err
+				}
+				line := r.line
+				var ocrData []input.Datum
+				if ocrCorrect {
+					corrected, substitutions, ok := iso6346.CorrectOCR(line)
+					if ok {
+						line = corrected
+					}
+					ocrData = append(ocrData, newOCRCorrectionsDatum(substitutions))
+				}
+				pattern := input.Match(line, patterns)
+				inputs, inputErr = input.Validate(line, pattern.NewInputs)
+				data := append(r.data, input.NewDatum("pattern").WithValue(pattern.Name))
+				err = printer.Print(inputs, append(data, ocrData...)...)
 				if err != nil {
 					return err
 				}
@@ -236,27 +426,45 @@ icm validate APL U 689473 0`,
 	validateCmd.Flags().SortFlags = false
 
 	validateCmd.Flags().VarP(pValue, configs.FlagNames.Pattern, "p",
-		fmt.Sprintf("sets pattern matching to %s, %s, %s, %s or %s\n%s\n",
-			auto, containerNumber, owner, ownerEquipmentCategory, sizeType,
+		fmt.Sprintf("sets pattern matching to %s, %s, %s, %s, %s, %s or %s\n%s\n",
+			auto, containerNumber, owner, ownerEquipmentCategory, sizeType, ilu, legacySizeType,
 			patternsInfo))
 	err := validateCmd.RegisterFlagCompletionFunc(configs.FlagNames.Pattern, func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{auto, containerNumber, owner, ownerEquipmentCategory, sizeType}, cobra.ShellCompDirectiveNoFileComp
+		return []string{auto, containerNumber, owner, ownerEquipmentCategory, sizeType, ilu, legacySizeType}, cobra.ShellCompDirectiveNoFileComp
 	})
 	if err != nil {
 		return nil, err
 	}
 	validateCmd.Flags().Var(oValue, configs.FlagNames.Output,
-		fmt.Sprintf("sets output to %s, %s or %s\n%s\n",
-			outputAuto, outputFancy, outputCSV,
+		fmt.Sprintf("sets output to %s, %s, %s, %s or %s\n%s\n",
+			outputAuto, outputFancy, outputCSV, outputJSON, outputNDJSON,
 			outputsInfo))
 	err = validateCmd.RegisterFlagCompletionFunc(configs.FlagNames.Output, func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{outputAuto, outputFancy, outputCSV}, cobra.ShellCompDirectiveNoFileComp
+		return []string{outputAuto, outputFancy, outputCSV, outputJSON, outputNDJSON}, cobra.ShellCompDirectiveNoFileComp
 	})
 	if err != nil {
 		return nil, err
 	}
 	validateCmd.Flags().Bool(configs.FlagNames.NoHeader, configs.DefaultValues.NoHeader,
 		"omits header of CSV output")
+	validateCmd.Flags().Bool(configs.FlagNames.NormalizeSizeType, configs.DefaultValues.NormalizeSizeType,
+		"adds current size-type code of current and legacy size-type codes")
+	validateCmd.Flags().StringArrayVar(&inputFiles, "input-file", nil,
+		"validates lines of file instead of arguments or stdin (repeatable)")
+	validateCmd.Flags().StringVar(&column, "column", "",
+		"validates column of CSV input and keeps the original columns")
+	validateCmd.Flags().Var(&inputFormat, "input-format",
+		fmt.Sprintf("sets input format to %s, %s or %s\n%s\n", inputFormatLines, inputFormatEDIFACT, inputFormatX12, inputFormatsInfo))
+	err = validateCmd.RegisterFlagCompletionFunc("input-format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{inputFormatLines, inputFormatEDIFACT, inputFormatX12}, cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		return nil, err
+	}
+	validateCmd.Flags().BoolVar(&ocrCorrect, "ocr-correct", false,
+		"replaces characters commonly confused by OCR if the check digit is then valid")
+	validateCmd.Flags().String(configs.FlagNames.Delimiter, configs.DefaultValues.Delimiter,
+		"delimiter of CSV input and output")
 	validateCmd.Flags().String(configs.FlagNames.SepOE, configs.DefaultValues.SepOE,
 		"ABC(x)U1234560   20G1  (x) separates owner code and equipment category id")
 	validateCmd.Flags().String(configs.FlagNames.SepES, configs.DefaultValues.SepES,
@@ -284,15 +492,28 @@ func isSingleLine(s string) bool {
 
 func newFancyPrinter(writer io.Writer, config *configs.Config) input.Printer {
 	fancyPrinter := input.NewFancyPrinter(writer)
-	fancyPrinter.SetIndent("  ")
+	fancyPrinter.SetIndent("  ").SetNotes(ocrCorrectionsHeader)
 	fancyPrinter.SetSeparatorsFunc(func(inputs []input.Input) {
+		switch {
 		// only size-type has 3 inputs
-		if len(inputs) == 3 {
+		case len(inputs) == 3:
 			fancyPrinter.SetSeparators(
 				"",
 				config.SepST(),
 			)
-		} else {
+		// only patterns with legacy country code or legacy size-type code have 5 inputs
+		// and only the pattern with legacy country code and size-type code has 8 inputs
+		case len(inputs) == 5 || len(inputs) == 8:
+			fancyPrinter.SetSeparators(
+				config.SepOE(),
+				config.SepES(),
+				config.SepSC(),
+				config.SepCS(),
+				config.SepCS(),
+				"",
+				config.SepST(),
+			)
+		default:
 			fancyPrinter.SetSeparators(
 				config.SepOE(),
 				config.SepES(),
@@ -306,125 +527,356 @@ func newFancyPrinter(writer io.Writer, config *configs.Config) input.Printer {
 	return fancyPrinter
 }
 
-func newCSVPrinter(writer io.Writer, config *configs.Config) input.Printer {
+const ocrCorrectionsHeader = "ocr-corrections"
+
+type ocrCorrection struct {
+	Position int    `json:"position"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// reservedColumns returns the CSV headers and JSON keys that validate prints
+// for patterns. Columns of CSV files must not have these names.
+func reservedColumns(patterns []input.Pattern) []string {
+	reserved := []string{"file", "line", "pattern", "ocr-corrections", "error-code", "valid", "errors"}
+	for _, column := range slices.Concat(input.Headers(patterns), input.Keys(patterns)) {
+		if !slices.Contains(reserved, column) {
+			reserved = append(reserved, column)
+		}
+	}
+	return reserved
+}
+
+// newOCRCorrectionsDatum returns a datum with the substitutions of iso6346.CorrectOCR.
+func newOCRCorrectionsDatum(substitutions []iso6346.OCRSubstitution) input.Datum {
+	substitutionsFmt := make([]string, 0, len(substitutions))
+	corrections := make([]ocrCorrection, 0, len(substitutions))
+	for _, s := range substitutions {
+		substitutionsFmt = append(substitutionsFmt, fmt.Sprintf("%c -> %c (position %d)", s.From, s.To, s.Pos))
+		corrections = append(corrections, ocrCorrection{s.Pos, string(s.From), string(s.To)})
+	}
+	return input.NewDatum(ocrCorrectionsHeader).WithTypedValue(strings.Join(substitutionsFmt, ", "), corrections)
+}
+
+func numberLayout(config *configs.Config) iso6346.Layout {
+	return iso6346.Layout{
+		SepOE: config.SepOE(),
+		SepES: config.SepES(),
+		SepSC: config.SepSC(),
+	}
+}
+
+func newCSVPrinter(writer io.Writer, config *configs.Config, patterns patterns) input.Printer {
 	csvWriter := csv.NewWriter(writer)
-	csvWriter.Comma = ';'
-	return input.NewCSVPrinter(csvWriter, config.NoHeader())
+	csvWriter.Comma, _ = utf8.DecodeRuneInString(config.Delimiter())
+	return input.NewCSVPrinter(csvWriter, config.NoHeader()).SetHeaders(input.Headers(patterns))
+}
+
+func newJSONPrinter(writer io.Writer, indent string) input.Printer {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	return input.NewJSONPrinter(encoder)
 }
 
 func newAutoPattern(config *configs.Config, decoders decoders) patterns {
-	owner := newOwnerInput(decoders.ownerDecodeUpdater)
-	equipCat := newEquipCatInput(decoders.equipCatDecoder)
+	ownerCode := newOwnerInput(decoders.ownerDecodeUpdater)
+	equipCat := newEquipCatInput(decoders.equipCatDecoder, decoders.ownerDecodeUpdater)
 	serialNum := newSerialNumInput()
-	checkDigit := newCheckDigitInput(config)
+	checkDigit := newCheckDigitInput(config, decoders.ownerDecodeUpdater)
 	length := newLengthInput(decoders.lengthDecoder)
 	heightWidth := newHeightWidthInput(decoders.heightWidthDecoder)
-	typeAndGroup := newTypeAndGroupInput(decoders.typeDecoder)
+	typeAndGroup := newTypeAndGroupInput(config, decoders.sizeTypeDecoders)
+	country := newCountryInput(decoders.countryDecoder)
+	legacySizeTypeCode := newLegacySizeTypeInput(config, decoders)
 
+	// Four digits of a legacy size-type code are also a valid format of size-type,
+	// so legacy size-type is matched first. A legacy size-type is matched after the
+	// container number patterns, because the serial number has four digits, too.
 	return patterns{
-		{owner, equipCat, serialNum, checkDigit, length, heightWidth, typeAndGroup},
-		{owner, equipCat, serialNum, checkDigit},
-		{owner, equipCat},
-		{owner},
-		{length, heightWidth, typeAndGroup},
+		input.NewPattern(containerNumberCountrySizeType, ownerCode, equipCat, serialNum, checkDigit, country, length, heightWidth, typeAndGroup),
+		input.NewPattern(containerNumberLegacySizeType, ownerCode, equipCat, serialNum, checkDigit, legacySizeTypeCode),
+		input.NewPattern(containerNumberSizeType, ownerCode, equipCat, serialNum, checkDigit, length, heightWidth, typeAndGroup),
+		input.NewPattern(containerNumberCountry, ownerCode, equipCat, serialNum, checkDigit, country),
+		input.NewPattern(containerNumber, ownerCode, equipCat, serialNum, checkDigit),
+		input.NewPattern(ownerEquipmentCategory, ownerCode, equipCat),
+		input.NewPattern(owner, ownerCode),
+		input.NewPattern(legacySizeType, legacySizeTypeCode),
+		input.NewPattern(sizeType, length, heightWidth, typeAndGroup),
 	}
 }
 
 func newContNumPattern(config *configs.Config, decoders decoders) patterns {
-	owner := newOwnerInput(decoders.ownerDecodeUpdater)
-	equipCat := newEquipCatInput(decoders.equipCatDecoder)
+	ownerCode := newOwnerInput(decoders.ownerDecodeUpdater)
+	equipCat := newEquipCatInput(decoders.equipCatDecoder, decoders.ownerDecodeUpdater)
 	serialNum := newSerialNumInput()
-	checkDigit := newCheckDigitInput(config)
+	checkDigit := newCheckDigitInput(config, decoders.ownerDecodeUpdater)
 
-	return patterns{{owner, equipCat, serialNum, checkDigit}}
+	return patterns{input.NewPattern(containerNumber, ownerCode, equipCat, serialNum, checkDigit)}
 }
 
 func newOwnerPattern(decoders decoders) patterns {
-	owner := newOwnerInput(decoders.ownerDecodeUpdater)
-	return patterns{{owner}}
+	ownerCode := newOwnerInput(decoders.ownerDecodeUpdater)
+	return patterns{input.NewPattern(owner, ownerCode)}
 }
 
 func newOwnerEquipCatPattern(decoders decoders) patterns {
-	owner := newOwnerInput(decoders.ownerDecodeUpdater)
-	equipCat := newEquipCatInput(decoders.equipCatDecoder)
+	ownerCode := newOwnerInput(decoders.ownerDecodeUpdater)
+	equipCat := newEquipCatInput(decoders.equipCatDecoder, decoders.ownerDecodeUpdater)
 
-	return patterns{{owner, equipCat}}
+	return patterns{input.NewPattern(ownerEquipmentCategory, ownerCode, equipCat)}
 }
 
-func newSizeTypePattern(decoders decoders) patterns {
+func newSizeTypePattern(config *configs.Config, decoders decoders) patterns {
 	length := newLengthInput(decoders.lengthDecoder)
 	heightWidth := newHeightWidthInput(decoders.heightWidthDecoder)
-	typeAndGroup := newTypeAndGroupInput(decoders.typeDecoder)
+	typeAndGroup := newTypeAndGroupInput(config, decoders.sizeTypeDecoders)
 
-	return patterns{{length, heightWidth, typeAndGroup}}
+	return patterns{input.NewPattern(sizeType, length, heightWidth, typeAndGroup)}
 }
 
-func newOwnerInput(ownerDecoder data.OwnerDecoder) func() input.Input {
+func newLegacySizeTypePattern(config *configs.Config, decoders decoders) patterns {
+	legacySizeTypeCode := newLegacySizeTypeInput(config, decoders)
+
+	return patterns{input.NewPattern(legacySizeType, legacySizeTypeCode)}
+}
+
+// newILUPattern returns the pattern of an intermodal loading unit (ILU) code.
+// The owner is decoded by the ILU owner key of owner code and equipment category ID, e.g. ABCA.
+func newILUPattern(config *configs.Config, decoders decoders) patterns {
+	ownerCodes := newILUOwnerCodes(decoders.iluOwnerDecoder)
+	ownerCode := newILUOwnerCodeInput(ownerCodes)
+	equipCat := newILUEquipCatInput(decoders.iluOwnerDecoder)
+	serialNum := newSerialNumInput()
+	checkDigit := newCheckDigitInput(config, ownerCodes)
+
+	return patterns{input.NewPattern(ilu, ownerCode, equipCat, serialNum, checkDigit)}
+}
+
+// iluOwnerCodes decodes the owner codes of ILU owner keys, e.g. ABC of ABCA.
+// The owner of an owner code is unknown because it is registered per ILU owner key.
+type iluOwnerCodes struct {
+	codes []string
+}
+
+func newILUOwnerCodes(iluOwnerDecoder data.OwnerDecoder) iluOwnerCodes {
+	var codes []string
+	for _, key := range iluOwnerDecoder.GetAllOwnerCodes() {
+		codes = append(codes, key[:3])
+	}
+	slices.Sort(codes)
+	return iluOwnerCodes{codes: slices.Compact(codes)}
+}
+
+func (c iluOwnerCodes) Decode(code string) (bool, iso6346.Owner) {
+	if _, found := slices.BinarySearch(c.codes, code); !found {
+		return false, iso6346.Owner{}
+	}
+	return true, iso6346.Owner{Code: code}
+}
+
+func (c iluOwnerCodes) DecodeSources(string) []data.SourcedOwner {
+	return nil
+}
+
+func (c iluOwnerCodes) GetAllOwnerCodes() []string {
+	return c.codes
+}
+
+// newILUOwnerCodeInput returns the input of the owner code of an ILU owner key.
+// The owner is decoded by the input of the equipment category ID.
+func newILUOwnerCodeInput(ownerCodes iluOwnerCodes) func() input.Input {
 	owner := input.NewInput(
 		3,
 		regexp.MustCompile(`[A-Za-z]{3}`).FindStringIndex,
 		func(value string, _ []string) ([]string, []input.Datum, error) {
-			ownerCodeDatum := input.NewDatum("owner-code")
-			ownerCompanyDatum := input.NewDatum("company")
-			ownerCityDatum := input.NewDatum("city")
-			ownerCountryDatum := input.NewDatum("country")
+			ownerCodeDatum := input.NewDatum("owner-code").InObject("owner", "code")
+			if _, err := decodeOwner(ownerCodes, value); err != nil {
+				return nil, []input.Datum{ownerCodeDatum}, err
+			}
+			return nil, []input.Datum{ownerCodeDatum.WithValue(value)}, nil
+		})
+	owner.SetToUpper()
+	return func() input.Input { return owner }
+}
 
-			if value == "" {
+const iluInfo = "intermodal loading unit"
+
+func newILUEquipCatInput(iluOwnerDecoder data.OwnerDecoder) func() input.Input {
+	equipCat := input.NewInput(
+		1,
+		regexp.MustCompile(`[A-Za-z]`).FindStringIndex,
+		func(value string, previousValues []string) ([]string, []input.Datum, error) {
+			equipCatIDDatum := input.NewDatum("equipment-category-id").WithValue(value)
+			equipCatDatum := input.NewDatum("equipment-category")
+			if err := iso6346.IsILUEquipCatID(value); err != nil {
+				kind := iso6346.ErrorKindUnknownEquipCatID
+				if value == "" {
+					kind = iso6346.ErrorKindBadLength
+				}
 				return nil,
-					[]input.Datum{ownerCodeDatum, ownerCompanyDatum, ownerCityDatum, ownerCountryDatum},
-					newValidateError(fmt.Sprintf("%s is not %s long (e.g. %s)",
-						au.Underline("owner code"),
-						au.Bold("3 letters"),
-						au.Underline(ownerDecoder.GetAllOwnerCodes()[0])))
+					append(ownerInfoData(nil, ""), equipCatIDDatum, equipCatDatum),
+					newValidateError(kind, iso6346.FieldEquipCatID, strings.Join(iso6346.ILUEquipCatIDs(), ", "),
+						fmt.Sprintf("%s is not %s, %s, %s, %s or %s",
+							au.Underline("equipment category id"),
+							au.Green("A"), au.Green("B"), au.Green("C"), au.Green("D"), au.Green("K")))
+			}
+
+			if len(previousValues[0]) != 3 {
+				return []string{iluInfo},
+					append(ownerInfoData(nil, ""), equipCatIDDatum, equipCatDatum.WithValue(iluInfo)),
+					nil
 			}
-			found, owner := ownerDecoder.Decode(value)
+
+			ownerKey := previousValues[0] + value
+			found, owner := iluOwnerDecoder.Decode(ownerKey)
 			if !found {
 				return nil,
-					[]input.Datum{ownerCodeDatum, ownerCompanyDatum, ownerCityDatum, ownerCountryDatum},
-					newValidateError(fmt.Sprintf("%s is not %s (e.g. %s)",
-						au.Underline(value),
-						au.Bold("registered"),
-						au.Underline(ownerDecoder.GetAllOwnerCodes()[0])))
+					append(ownerInfoData(nil, ""), equipCatIDDatum, equipCatDatum),
+					newValidateError(iso6346.ErrorKindOwnerNotRegistered, iso6346.FieldEquipCatID, "",
+						fmt.Sprintf("%s is not a %s",
+							au.Underline(ownerKey),
+							au.Bold("registered ILU owner key")))
+			}
+			return []string{iluInfo, owner.Company, owner.City, owner.Country},
+				append(ownerInfoData(&owner, ownerSource(iluOwnerDecoder, ownerKey)), equipCatIDDatum, equipCatDatum.WithValue(iluInfo)),
+				nil
+		})
+	equipCat.SetToUpper()
+	return func() input.Input { return equipCat }
+}
+
+func newOwnerInput(ownerDecoder data.OwnerDecoder) func() input.Input {
+	owner := input.NewInput(
+		3,
+		regexp.MustCompile(`[A-Za-z]{3}`).FindStringIndex,
+		func(value string, _ []string) ([]string, []input.Datum, error) {
+			ownerCodeDatum := input.NewDatum("owner-code").InObject("owner", "code")
+
+			owner, err := decodeOwner(ownerDecoder, value)
+			if err != nil {
+				return nil, append([]input.Datum{ownerCodeDatum}, ownerInfoData(nil, "")...), err
 			}
 			return []string{
 					owner.Company,
 					owner.City,
 					owner.Country,
 				},
-				[]input.Datum{
-					ownerCodeDatum.WithValue(owner.Code),
-					ownerCompanyDatum.WithValue(owner.Company),
-					ownerCityDatum.WithValue(owner.City),
-					ownerCountryDatum.WithValue(owner.Country),
-				},
+				append([]input.Datum{ownerCodeDatum.WithValue(owner.Code)}, ownerInfoData(&owner, ownerSource(ownerDecoder, value))...),
 				nil
 		})
 	owner.SetToUpper()
 	return func() input.Input { return owner }
 }
 
-func newEquipCatInput(equipCatDecoder data.EquipCatDecoder) func() input.Input {
+// decodeOwner returns the owner of an owner code or an error with suggested owner codes
+// if the owner code is not registered.
+func decodeOwner(ownerDecoder data.OwnerDecoder, value string) (iso6346.Owner, error) {
+	if value == "" {
+		return iso6346.Owner{}, newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldOwnerCode, "3",
+			fmt.Sprintf("%s is not %s long%s",
+				au.Underline("owner code"),
+				au.Bold("3 letters"),
+				ownerCodeExample(ownerDecoder)))
+	}
+	found, owner := ownerDecoder.Decode(value)
+	if found {
+		return owner, nil
+	}
+	suggestions := iso6346.SuggestOwnerCodes(value, ownerDecoder.GetAllOwnerCodes(), 1)
+	if len(suggestions) == 0 {
+		return iso6346.Owner{}, newValidateError(iso6346.ErrorKindOwnerNotRegistered, iso6346.FieldOwnerCode, "",
+			fmt.Sprintf("%s is not %s%s",
+				au.Underline(value),
+				au.Bold("registered"),
+				ownerCodeExample(ownerDecoder)))
+	}
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	suggestionsFmt := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		suggestionsFmt[i] = fmt.Sprint(au.Underline(suggestion))
+	}
+	return iso6346.Owner{}, newValidateError(iso6346.ErrorKindOwnerNotRegistered, iso6346.FieldOwnerCode, "",
+		fmt.Sprintf("%s is not %s (did you mean %s?)",
+			au.Underline(value),
+			au.Bold("registered"),
+			strings.Join(suggestionsFmt, ", ")))
+}
+
+// ownerInfoData returns the data of company, city, country and owner source of an owner
+// or data without values if there is no owner.
+func ownerInfoData(owner *iso6346.Owner, source string) []input.Datum {
+	ownerCompanyDatum := input.NewDatum("company").InObject("owner", "company")
+	ownerCityDatum := input.NewDatum("city").InObject("owner", "city")
+	ownerCountryDatum := input.NewDatum("country").InObject("owner", "country")
+	ownerSourceDatum := input.NewDatum("owner-source").InObject("owner", "source")
+	if owner == nil {
+		return []input.Datum{ownerCompanyDatum, ownerCityDatum, ownerCountryDatum, ownerSourceDatum}
+	}
+	return []input.Datum{
+		ownerCompanyDatum.WithValue(owner.Company),
+		ownerCityDatum.WithValue(owner.City),
+		ownerCountryDatum.WithValue(owner.Country),
+		ownerSourceDatum.WithValue(source),
+	}
+}
+
+// ownerCodeExample returns an example of a registered owner code for error messages
+// or nothing if no owner code is registered, e.g. in the empty ILU owner file.
+func ownerCodeExample(ownerDecoder data.OwnerDecoder) string {
+	codes := ownerDecoder.GetAllOwnerCodes()
+	if len(codes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (e.g. %s)", au.Underline(codes[0]))
+}
+
+// ownerSource returns the owner source of the owner that is returned by Decode.
+func ownerSource(ownerDecoder data.OwnerDecoder, code string) string {
+	sourcedOwners := ownerDecoder.DecodeSources(code)
+	if len(sourcedOwners) == 0 {
+		return ""
+	}
+	return sourcedOwners[len(sourcedOwners)-1].Source
+}
+
+func newEquipCatInput(equipCatDecoder data.EquipCatDecoder, ownerDecoder data.OwnerDecoder) func() input.Input {
 	equipCat := input.NewInput(
 		1,
 		regexp.MustCompile(`[A-Za-z]`).FindStringIndex,
-		func(value string, _ []string) ([]string, []input.Datum, error) {
+		func(value string, previousValues []string) ([]string, []input.Datum, error) {
 			equipCatIDDatum := input.NewDatum("equipment-category-id").WithValue(value)
 			equipCatDatum := input.NewDatum("equipment-category")
 			if value == "" {
 				return nil,
 					[]input.Datum{equipCatIDDatum, equipCatDatum},
-					newValidateError(fmt.Sprintf("%s is not %s",
-						au.Underline("equipment category id"),
-						equipCatIDsAsList(equipCatDecoder)))
+					newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldEquipCatID, strings.Join(equipCatIDs(equipCatDecoder), ", "),
+						fmt.Sprintf("%s is not %s",
+							au.Underline("equipment category id"),
+							equipCatIDsAsList(equipCatDecoder)))
 			}
 
 			found, cat := equipCatDecoder.Decode(value)
 			if !found {
 				return nil,
 					[]input.Datum{equipCatIDDatum, equipCatDatum},
-					newValidateError(fmt.Sprintf("%s is not %s",
-						au.Underline("equipment category id"),
-						equipCatIDsAsList(equipCatDecoder)))
+					newValidateError(iso6346.ErrorKindUnknownEquipCatID, iso6346.FieldEquipCatID, strings.Join(equipCatIDs(equipCatDecoder), ", "),
+						fmt.Sprintf("%s is not %s",
+							au.Underline("equipment category id"),
+							equipCatIDsAsList(equipCatDecoder)))
+			}
+
+			// An owner code is registered per equipment category ID, e.g. ABCU, but not ABCJ.
+			if found, o := ownerDecoder.Decode(previousValues[0]); found && !o.IsEquipCatIDRegistered(value) {
+				return []string{cat.Info},
+					[]input.Datum{equipCatIDDatum, equipCatDatum.WithValue(cat.Info)},
+					newValidateError(iso6346.ErrorKindEquipCatIDNotRegistered, iso6346.FieldEquipCatID, strings.Join(strings.Split(o.EquipCatIDs, ""), ", "),
+						fmt.Sprintf("%s is not %s for owner %s",
+							au.Underline("equipment category id"),
+							au.Bold("registered"),
+							au.Bold(o.Code)))
 			}
 			return []string{cat.Info},
 				[]input.Datum{equipCatIDDatum, equipCatDatum.WithValue(cat.Info)},
@@ -434,11 +886,16 @@ func newEquipCatInput(equipCatDecoder data.EquipCatDecoder) func() input.Input {
 	return func() input.Input { return equipCat }
 }
 
+func equipCatIDs(equipCatDecoder data.EquipCatDecoder) []string {
+	iDs := equipCatDecoder.AllCatIDs()
+	slices.Sort(iDs)
+	return iDs
+}
+
 func equipCatIDsAsList(equipCatDecoder data.EquipCatDecoder) string {
 	b := strings.Builder{}
 
-	iDs := equipCatDecoder.AllCatIDs()
-	slices.Sort(iDs)
+	iDs := equipCatIDs(equipCatDecoder)
 	for i, element := range iDs {
 		b.WriteString(fmt.Sprint(au.Green(element)))
 		if i < len(iDs)-2 {
@@ -461,40 +918,53 @@ func newSerialNumInput() func() input.Input {
 				if value == "" {
 					return nil,
 						[]input.Datum{serialNumData},
-						newValidateError(fmt.Sprintf("%s is not %s long",
-							au.Underline("serial number"),
-							au.Bold("6 numbers")))
+						newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldSerialNumber, "6",
+							fmt.Sprintf("%s is not %s long",
+								au.Underline("serial number"),
+								au.Bold("6 numbers")))
 				}
 				return nil, []input.Datum{serialNumData.WithValue(value)}, nil
 			})
 	}
 }
 
-func newCheckDigitInput(config *configs.Config) func() input.Input {
+func newCheckDigitInput(config *configs.Config, ownerDecoder data.OwnerDecoder) func() input.Input {
 	return func() input.Input {
 		return input.NewInput(
 			1,
 			regexp.MustCompile(`\d`).FindStringIndex,
 			func(value string, previousValues []string) ([]string, []input.Datum, error) {
-				checkDigitDatum := input.NewDatum("check-digit").WithValue(value)
+				// The check digit is a number like the calculated check digit and lists
+				// are empty instead of null, so JSON consumers get the same types.
+				checkDigitDatum := input.NewDatum("check-digit")
+				if number, err := strconv.Atoi(value); err == nil {
+					checkDigitDatum = checkDigitDatum.WithIntValue(number)
+				}
 				calcCheckDigitDatum := input.NewDatum("calculated-check-digit")
 				validCheckDigit := input.NewDatum("valid-check-digit")
-				errorProneSerialNumbers := input.NewDatum("possible-transposition-error")
+				errorProneSerialNumbers := input.NewDatum("possible-transposition-error").WithListValue(nil)
+				errorProneClassesDatum := input.NewDatum("error-prone-serial-numbers").WithTypedValue("", []errorProneContNum{})
+				checkDigit10CollisionsDatum := input.NewDatum("check-digit-10-collision").WithListValue(nil)
+				suggestionsDatum := input.NewDatum("suggestions").WithListValue(nil)
 				if len(strings.Join(previousValues[0:3], "")) != 10 {
 					return nil,
 						[]input.Datum{
 							checkDigitDatum,
 							calcCheckDigitDatum,
-							validCheckDigit.WithValue(fmt.Sprintf("%t", false)),
+							validCheckDigit.WithBoolValue(false),
 							errorProneSerialNumbers,
+							errorProneClassesDatum,
+							checkDigit10CollisionsDatum,
+							suggestionsDatum,
 						},
-						newValidateError(fmt.Sprintf("%s is not calculable",
-							au.Underline("check digit")))
+						newValidateError(iso6346.ErrorKindCheckDigitNotCalculable, iso6346.FieldCheckDigit, "",
+							fmt.Sprintf("%s is not calculable",
+								au.Underline("check digit")))
 				}
 
 				equipCatID, _ := utf8.DecodeRuneInString(previousValues[1])
 				serialNum, _ := strconv.Atoi(previousValues[0])
-				checkDigit := cont.CalcCheckDigit(previousValues[2], equipCatID, serialNum)
+				checkDigit := iso6346.CalcCheckDigit(previousValues[2], equipCatID, serialNum)
 
 				var lines []string
 				if checkDigit == 10 {
@@ -505,99 +975,204 @@ func newCheckDigitInput(config *configs.Config) func() input.Input {
 					)
 				}
 
+				original := iso6346.Number{
+					OwnerCode:    previousValues[2],
+					EquipCatID:   equipCatID,
+					SerialNumber: serialNum,
+					CheckDigit:   checkDigit % 10,
+				}
+
 				number, err := strconv.Atoi(value)
 				if err != nil {
-					return lines,
+					suggestions := suggestNumbers(ownerDecoder, original)
+					return append(lines, suggestionLines(original, suggestions, config)...),
 						[]input.Datum{
 							checkDigitDatum,
-							calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
-							validCheckDigit.WithValue(fmt.Sprintf("%t", false)),
+							calcCheckDigitDatum.WithIntValue(checkDigit),
+							validCheckDigit.WithBoolValue(false),
 							errorProneSerialNumbers,
+							errorProneClassesDatum,
+							checkDigit10CollisionsDatum,
+							suggestionsDatum.WithListValue(formatNumbers(suggestions, config)),
 						},
-						newValidateError(fmt.Sprintf("%s must be a %s (calculated: %s)",
-							au.Underline("check digit"),
-							au.Bold("number"),
-							au.Green(strconv.Itoa(checkDigit))))
+						newValidateError(iso6346.ErrorKindBadFormat, iso6346.FieldCheckDigit, strconv.Itoa(checkDigit%10),
+							fmt.Sprintf("%s must be a %s (calculated: %s)",
+								au.Underline("check digit"),
+								au.Bold("number"),
+								au.Green(strconv.Itoa(checkDigit))))
 				}
 
+				original.CheckDigit = number
 				if number != checkDigit%10 {
-					return lines,
+					suggestions := suggestNumbers(ownerDecoder, original)
+					return append(lines, suggestionLines(original, suggestions, config)...),
 						[]input.Datum{
 							checkDigitDatum,
-							calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
-							validCheckDigit.WithValue(fmt.Sprintf("%t", number == checkDigit%10)),
+							calcCheckDigitDatum.WithIntValue(checkDigit),
+							validCheckDigit.WithBoolValue(number == checkDigit%10),
 							errorProneSerialNumbers,
+							errorProneClassesDatum,
+							checkDigit10CollisionsDatum,
+							suggestionsDatum.WithListValue(formatNumbers(suggestions, config)),
 						},
-						newValidateError(fmt.Sprintf(
-							"calculated %s is %s",
-							au.Underline("check digit"),
-							au.Green(strconv.Itoa(checkDigit%10))))
+						newValidateError(iso6346.ErrorKindCheckDigitMismatch, iso6346.FieldCheckDigit, strconv.Itoa(checkDigit%10),
+							fmt.Sprintf(
+								"calculated %s is %s",
+								au.Underline("check digit"),
+								au.Green(strconv.Itoa(checkDigit%10))))
 				}
 
-				transposedContNums := cont.CheckTransposition(previousValues[2], equipCatID, serialNum, checkDigit)
+				errorProneContNums := iso6346.CheckErrorProne(previousValues[2], equipCatID, serialNum, checkDigit)
 
-				if transposedContNums != nil {
+				var transposedContNums []string
+				errorProneContNumsFmt := []string{}
+				errorProneContNumsJSON := []errorProneContNum{}
+				if errorProneContNums != nil {
 					lines = append(lines, "Error-prone serial numbers:")
-					builder := strings.Builder{}
-
-					for idx, tcn := range transposedContNums {
-
-						serialNumber := fmt.Sprintf("%06d", tcn.SerialNumber)
-						serialNumberFmt := ""
-						for i := 0; i < len(serialNumber); i++ {
-							if i == tcn.Pos {
-								serialNumberFmt += fmt.Sprintf("%c", au.Magenta(serialNumber[i]))
-								// last serial number digit
-								if i == len(serialNumber)-1 {
-									continue
-								}
-								serialNumberFmt += fmt.Sprintf("%c", au.Magenta(serialNumber[i+1]))
-								i++
-							} else {
-								serialNumberFmt += fmt.Sprintf("%c", serialNumber[i])
-							}
-						}
-
-						var digitFmt string
-						if tcn.Pos == 5 {
-							digitFmt = fmt.Sprintf("%d", au.Magenta(tcn.CheckDigit))
-						} else {
-							digitFmt = fmt.Sprintf("%d", tcn.CheckDigit)
-						}
-
-						contNumFmt := fmt.Sprintf("%s%s%s%s%s%s%s",
-							tcn.OwnerCode, config.SepOE(),
-							string(tcn.EquipCatID), config.SepES(),
-							serialNumberFmt, config.SepSC(),
-							digitFmt)
-						lines = append(lines, fmt.Sprintf("  %s", contNumFmt))
-						builder.WriteString(contNumFmt)
-						if idx < len(transposedContNums)-1 {
-							builder.WriteString(", ")
-						}
+				}
+				for _, epn := range errorProneContNums {
+					lines = append(lines, fmt.Sprintf("  %s  %s", highlightChangedDigits(original, epn.Number, config), epn.Class))
+
+					contNum := epn.Format(numberLayout(config))
+					if epn.Class == iso6346.ErrorClassTransposition {
+						transposedContNums = append(transposedContNums, contNum)
 					}
-					return lines,
-						[]input.Datum{
-							checkDigitDatum,
-							calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
-							validCheckDigit.WithValue(fmt.Sprintf("%t", number == checkDigit%10)),
-							errorProneSerialNumbers.WithValue(builder.String()),
-						},
-						nil
+					errorProneContNumsFmt = append(errorProneContNumsFmt, fmt.Sprintf("%s (%s)", contNum, epn.Class))
+					errorProneContNumsJSON = append(errorProneContNumsJSON, errorProneContNum{contNum, epn.Class, epn.Pos})
 				}
 
+				collisions := iso6346.CheckDigit10Collisions(original.OwnerCode, original.EquipCatID, original.SerialNumber)
+
 				return lines,
 					[]input.Datum{
 						checkDigitDatum,
-						calcCheckDigitDatum.WithValue(strconv.Itoa(checkDigit)),
-						validCheckDigit.WithValue(fmt.Sprintf("%t", number == checkDigit%10)),
-						errorProneSerialNumbers,
+						calcCheckDigitDatum.WithIntValue(checkDigit),
+						validCheckDigit.WithBoolValue(number == checkDigit%10),
+						errorProneSerialNumbers.WithListValue(transposedContNums),
+						errorProneClassesDatum.WithTypedValue(strings.Join(errorProneContNumsFmt, ", "), errorProneContNumsJSON),
+						checkDigit10CollisionsDatum.WithListValue(formatNumbers(collisions, config)),
+						suggestionsDatum,
 					},
 					nil
 			})
 	}
 }
 
+type errorProneContNum struct {
+	ContNum string             `json:"container-number"`
+	Class   iso6346.ErrorClass `json:"class"`
+	Pos     int                `json:"position"`
+}
+
+const maxSuggestions = 5
+
+// suggestNumbers returns the most likely intended container numbers for n with a
+// mismatched check digit. For an unregistered owner code only registered owner codes
+// that differ in one letter and have a valid check digit are suggested. Otherwise
+// corrections of serial number and check digit are suggested.
+func suggestNumbers(ownerDecoder data.OwnerDecoder, n iso6346.Number) []iso6346.Number {
+	var numbers []iso6346.Number
+	if found, _ := ownerDecoder.Decode(n.OwnerCode); !found {
+		for _, code := range iso6346.SuggestOwnerCodes(n.OwnerCode, ownerDecoder.GetAllOwnerCodes(), 1) {
+			candidate := n
+			candidate.OwnerCode = code
+			if iso6346.ValidateCheckDigit(candidate, false) == nil {
+				numbers = append(numbers, candidate)
+			}
+		}
+	} else {
+		for _, correction := range iso6346.SuggestCorrections(n) {
+			numbers = append(numbers, correction.Number)
+		}
+	}
+	if len(numbers) > maxSuggestions {
+		numbers = numbers[:maxSuggestions]
+	}
+	return numbers
+}
+
+func suggestionLines(original iso6346.Number, suggestions []iso6346.Number, config *configs.Config) []string {
+	if len(suggestions) == 0 {
+		return nil
+	}
+	lines := []string{"Did you mean:"}
+	for _, suggestion := range suggestions {
+		lines = append(lines, fmt.Sprintf("  %s", highlightChangedDigits(original, suggestion, config)))
+	}
+	return lines
+}
+
+func formatNumbers(numbers []iso6346.Number, config *configs.Config) []string {
+	var formatted []string
+	for _, n := range numbers {
+		formatted = append(formatted, n.Format(numberLayout(config)))
+	}
+	return formatted
+}
+
+// highlightChangedDigits returns the formatted container number with
+// the owner code letters and digits highlighted that differ from original.
+func highlightChangedDigits(original, changed iso6346.Number, config *configs.Config) string {
+	ownerCodeFmt := make([]string, len(changed.OwnerCode))
+	for i := range changed.OwnerCode {
+		if i < len(original.OwnerCode) && changed.OwnerCode[i] == original.OwnerCode[i] {
+			ownerCodeFmt[i] = string(changed.OwnerCode[i])
+		} else {
+			ownerCodeFmt[i] = fmt.Sprintf("%c", au.Magenta(changed.OwnerCode[i]))
+		}
+	}
+	originalDigits := fmt.Sprintf("%06d%d", original.SerialNumber, original.CheckDigit)
+	changedDigits := fmt.Sprintf("%06d%d", changed.SerialNumber, changed.CheckDigit)
+	digitsFmt := make([]string, len(changedDigits))
+	for i := range changedDigits {
+		if changedDigits[i] != originalDigits[i] {
+			digitsFmt[i] = fmt.Sprintf("%c", au.Magenta(changedDigits[i]))
+		} else {
+			digitsFmt[i] = string(changedDigits[i])
+		}
+	}
+	return fmt.Sprintf("%s%s%s%s%s%s%s",
+		strings.Join(ownerCodeFmt, ""), config.SepOE(),
+		string(changed.EquipCatID), config.SepES(),
+		strings.Join(digitsFmt[:6], ""), config.SepSC(),
+		digitsFmt[6])
+}
+
+// newCountryInput returns the input of a legacy country code that was marked
+// between check digit and size and type code before ISO 6346:1995.
+func newCountryInput(countryDecoder data.CountryDecoder) func() input.Input {
+	country := input.NewInput(
+		2,
+		regexp.MustCompile(`[A-Za-z]{2}`).FindStringIndex,
+		func(value string, _ []string) ([]string, []input.Datum, error) {
+			countryCodeDatum := input.NewDatum("legacy-country-code").WithValue(value)
+			countryNameDatum := input.NewDatum("legacy-country")
+			if value == "" {
+				return nil,
+					[]input.Datum{countryCodeDatum, countryNameDatum},
+					newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldCountryCode, "2",
+						fmt.Sprintf("%s is not %s long",
+							au.Underline("country code"),
+							au.Bold("2 letters")))
+			}
+
+			found, country := countryDecoder.Decode(value)
+			if !found {
+				return nil,
+					[]input.Datum{countryCodeDatum, countryNameDatum},
+					newValidateError(iso6346.ErrorKindUnknownCountryCode, iso6346.FieldCountryCode, "",
+						fmt.Sprintf("%s is not a %s",
+							au.Underline("country code"),
+							au.Bold("valid legacy country code")))
+			}
+			return []string{country.Name},
+				[]input.Datum{countryCodeDatum, countryNameDatum.WithValue(country.Name)},
+				nil
+		})
+	country.SetToUpper()
+	return func() input.Input { return country }
+}
+
 func newLengthInput(lengthDecoder data.LengthDecoder) func() input.Input {
 	length := input.NewInput(
 		1,
@@ -608,19 +1183,21 @@ func newLengthInput(lengthDecoder data.LengthDecoder) func() input.Input {
 			if value == "" {
 				return nil,
 					[]input.Datum{lengthDatum, lengthDescDatum},
-					newValidateError(fmt.Sprintf("%s is not a %s or a %s",
-						au.Underline("length code"),
-						au.Bold("valid number"),
-						au.Bold("valid character")))
+					newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldLengthCode, "1",
+						fmt.Sprintf("%s is not a %s or a %s",
+							au.Underline("length code"),
+							au.Bold("valid number"),
+							au.Bold("valid character")))
 			}
 
 			found, length := lengthDecoder.Decode(value)
 			if !found {
 				return nil,
 					[]input.Datum{lengthDatum, lengthDescDatum},
-					newValidateError(fmt.Sprintf("%s is not %s",
-						au.Underline("length code"),
-						au.Bold("valid")))
+					newValidateError(iso6346.ErrorKindUnknownLengthCode, iso6346.FieldLengthCode, "",
+						fmt.Sprintf("%s is not %s",
+							au.Underline("length code"),
+							au.Bold("valid")))
 			}
 			return []string{fmt.Sprintf("length: %s", length)},
 				[]input.Datum{lengthDatum, lengthDescDatum.WithValue(string(length))},
@@ -641,19 +1218,21 @@ func newHeightWidthInput(heightWidthDecoder data.HeightWidthDecoder) func() inpu
 			if value == "" {
 				return nil,
 					[]input.Datum{heightWidthDatum, heightDescDatum, widthDescDatum},
-					newValidateError(fmt.Sprintf("%s is not a %s or a %s",
-						au.Underline("height and width code"),
-						au.Bold("valid number"),
-						au.Bold("valid character")))
+					newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldHeightWidthCode, "1",
+						fmt.Sprintf("%s is not a %s or a %s",
+							au.Underline("height and width code"),
+							au.Bold("valid number"),
+							au.Bold("valid character")))
 			}
 
 			found, height, width := heightWidthDecoder.Decode(value)
 			if !found {
 				return nil,
 					[]input.Datum{heightWidthDatum, heightDescDatum, widthDescDatum},
-					newValidateError(fmt.Sprintf("%s is not %s",
-						au.Underline("height and width code"),
-						au.Bold("valid")))
+					newValidateError(iso6346.ErrorKindUnknownHeightWidthCode, iso6346.FieldHeightWidthCode, "",
+						fmt.Sprintf("%s is not %s",
+							au.Underline("height and width code"),
+							au.Bold("valid")))
 			}
 			return []string{
 					fmt.Sprintf("height: %s", height),
@@ -670,42 +1249,201 @@ func newHeightWidthInput(heightWidthDecoder data.HeightWidthDecoder) func() inpu
 	return func() input.Input { return heightWidth }
 }
 
-func newTypeAndGroupInput(typeDecoder data.TypeDecoder) func() input.Input {
+// sizeTypeCodeHeader is the header of the current size-type code of current and
+// legacy size-type codes if the size-type code is normalized.
+const sizeTypeCodeHeader = "size-type-code"
+
+// sizeTypeCodeData returns the datum of the size-type code if the size-type code is normalized.
+func sizeTypeCodeData(config *configs.Config, code string) []input.Datum {
+	if !config.NormalizeSizeType() {
+		return nil
+	}
+	return []input.Datum{input.NewDatum(sizeTypeCodeHeader).WithValue(code)}
+}
+
+func newTypeAndGroupInput(config *configs.Config, decoders sizeTypeDecoders) func() input.Input {
 	typeAndGroup := input.NewInput(
 		2,
 		regexp.MustCompile(`[A-Za-z\d]{2}`).FindStringIndex,
-		func(value string, _ []string) ([]string, []input.Datum, error) {
+		func(value string, previousValues []string) ([]string, []input.Datum, error) {
 			typeDatum := input.NewDatum("type-code").WithValue(value)
 			typeDescDatum := input.NewDatum("type-description")
 			groupDescDatum := input.NewDatum("group-description")
+			typeGroupDatum := input.NewDatum("type-group-code")
 			if value == "" {
 				return nil,
-					[]input.Datum{typeDatum, typeDescDatum, groupDescDatum},
-					newValidateError(fmt.Sprintf("%s is not a %s or a %s",
-						au.Underline("type code"),
-						au.Bold("valid number"),
-						au.Bold("valid character")))
+					append([]input.Datum{typeDatum, typeDescDatum, groupDescDatum, typeGroupDatum}, sizeTypeCodeData(config, "")...),
+					newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldTypeCode, "2",
+						fmt.Sprintf("%s is not a %s or a %s",
+							au.Underline("type code"),
+							au.Bold("valid number"),
+							au.Bold("valid character")))
+			}
+
+			// Booking systems often use type group codes like GP instead of detailed type codes like G1.
+			if found, typeGroupInfo, groupInfo := decoders.typeDecoder.DecodeTypeGroup(value); found {
+				return []string{
+						fmt.Sprintf("type group: %s", typeGroupInfo),
+						fmt.Sprintf("group:      %s", groupInfo),
+					},
+					append([]input.Datum{
+						typeDatum,
+						typeDescDatum.WithValue(string(typeGroupInfo)),
+						groupDescDatum.WithValue(string(groupInfo)),
+						typeGroupDatum.WithValue(value),
+					}, sizeTypeCodeData(config, normalizedSizeTypeCode(decoders, previousValues[1], previousValues[0], value))...),
+					nil
 			}
 
-			found, typeInfo, groupInfo := typeDecoder.Decode(value)
+			found, typeInfo, groupInfo := decoders.typeDecoder.Decode(value)
 			if !found {
 				return nil,
-					[]input.Datum{typeDatum, typeDescDatum, groupDescDatum},
-					newValidateError(fmt.Sprintf("%s is not %s",
-						au.Underline("type code"),
-						au.Bold("valid")))
+					append([]input.Datum{typeDatum, typeDescDatum, groupDescDatum, typeGroupDatum}, sizeTypeCodeData(config, "")...),
+					newValidateError(iso6346.ErrorKindUnknownTypeCode, iso6346.FieldTypeCode, "",
+						fmt.Sprintf("%s is not %s",
+							au.Underline("type code"),
+							au.Bold("valid")))
 			}
 			return []string{
 					fmt.Sprintf("type:  %s", typeInfo),
 					fmt.Sprintf("group: %s", groupInfo),
 				},
-				[]input.Datum{
+				append([]input.Datum{
 					typeDatum,
 					typeDescDatum.WithValue(string(typeInfo)),
 					groupDescDatum.WithValue(string(groupInfo)),
-				},
+					typeGroupDatum.WithValue(typeGroupCode(decoders.typeDecoder, value)),
+				}, sizeTypeCodeData(config, normalizedSizeTypeCode(decoders, previousValues[1], previousValues[0], value))...),
 				nil
 		})
 	typeAndGroup.SetToUpper()
 	return func() input.Input { return typeAndGroup }
 }
+
+// typeGroupCode returns the type group code of a type code or an empty string.
+func typeGroupCode(typeDecoder data.TypeDecoder, typeCode string) string {
+	_, code := typeDecoder.TypeGroupCode(typeCode)
+	return code
+}
+
+// isTypeOrTypeGroupCode returns true if the code is a known type code or type group code.
+func isTypeOrTypeGroupCode(typeDecoder data.TypeDecoder, code string) bool {
+	if found, _, _ := typeDecoder.Decode(code); found {
+		return true
+	}
+	found, _, _ := typeDecoder.DecodeTypeGroup(code)
+	return found
+}
+
+// normalizedSizeTypeCode returns the size-type code if all codes are decodable.
+func normalizedSizeTypeCode(decoders sizeTypeDecoders, lengthCode, heightWidthCode, typeCode string) string {
+	if found, _ := decoders.lengthDecoder.Decode(lengthCode); !found {
+		return ""
+	}
+	if found, _, _ := decoders.heightWidthDecoder.Decode(heightWidthCode); !found {
+		return ""
+	}
+	if !isTypeOrTypeGroupCode(decoders.typeDecoder, typeCode) {
+		return ""
+	}
+	return lengthCode + heightWidthCode + typeCode
+}
+
+func newLegacySizeTypeInput(config *configs.Config, decoders decoders) func() input.Input {
+	legacySizeTypeCode := input.NewInput(
+		4,
+		regexp.MustCompile(`\d{4}`).FindStringIndex,
+		func(value string, _ []string) ([]string, []input.Datum, error) {
+			legacyDatum := input.NewDatum("legacy-size-type-code").WithValue(value)
+			lengthDatum := input.NewDatum("length-code")
+			lengthDescDatum := input.NewDatum("length-description")
+			heightWidthDatum := input.NewDatum("height-width-code")
+			heightDescDatum := input.NewDatum("height-description")
+			widthDescDatum := input.NewDatum("width-description")
+			typeDatum := input.NewDatum("type-code")
+			typeDescDatum := input.NewDatum("type-description")
+			groupDescDatum := input.NewDatum("group-description")
+			typeGroupDatum := input.NewDatum("type-group-code")
+			emptyData := append([]input.Datum{
+				legacyDatum,
+				lengthDatum,
+				lengthDescDatum,
+				heightWidthDatum,
+				heightDescDatum,
+				widthDescDatum,
+				typeDatum,
+				typeDescDatum,
+				groupDescDatum,
+				typeGroupDatum,
+			}, sizeTypeCodeData(config, "")...)
+			if value == "" {
+				return nil,
+					emptyData,
+					newValidateError(iso6346.ErrorKindBadLength, iso6346.FieldLegacySizeTypeCode, "4",
+						fmt.Sprintf("%s is not %s",
+							au.Underline("legacy size-type code"),
+							au.Bold("4 numbers")))
+			}
+
+			code, lengthInfo, height, width, typeInfo, groupInfo, ok := decodeLegacySizeType(decoders, value)
+			if !ok {
+				return nil,
+					emptyData,
+					newValidateError(iso6346.ErrorKindUnknownLegacySizeTypeCode, iso6346.FieldLegacySizeTypeCode, "",
+						fmt.Sprintf("%s is not %s",
+							au.Underline("legacy size-type code"),
+							au.Bold("convertible")))
+			}
+			return []string{
+					fmt.Sprintf("size-type code: %s", code),
+					fmt.Sprintf("length: %s", lengthInfo),
+					fmt.Sprintf("height: %s", height),
+					fmt.Sprintf("width:  %s", width),
+					fmt.Sprintf("type:  %s", typeInfo),
+					fmt.Sprintf("group: %s", groupInfo),
+				},
+				append([]input.Datum{
+					legacyDatum,
+					lengthDatum.WithValue(code[:1]),
+					lengthDescDatum.WithValue(string(lengthInfo)),
+					heightWidthDatum.WithValue(code[1:2]),
+					heightDescDatum.WithValue(string(height)),
+					widthDescDatum.WithValue(string(width)),
+					typeDatum.WithValue(code[2:]),
+					typeDescDatum.WithValue(string(typeInfo)),
+					groupDescDatum.WithValue(string(groupInfo)),
+					typeGroupDatum.WithValue(typeGroupCode(decoders.typeDecoder, code[2:])),
+				}, sizeTypeCodeData(config, code)...),
+				nil
+		})
+	return func() input.Input { return legacySizeTypeCode }
+}
+
+// decodeLegacySizeType converts a legacy size-type code to the current size-type code and decodes it.
+func decodeLegacySizeType(decoders decoders, legacyCode string) (
+	code string,
+	length iso6346.Length,
+	height iso6346.Height,
+	width iso6346.Width,
+	typeInfo iso6346.TypeInfo,
+	groupInfo iso6346.GroupInfo,
+	ok bool,
+) {
+	found, code := decoders.legacySizeTypeDecoder.Decode(legacyCode)
+	if !found {
+		return
+	}
+	found, length = decoders.lengthDecoder.Decode(code[:1])
+	if !found {
+		return
+	}
+	found, height, width = decoders.heightWidthDecoder.Decode(code[1:2])
+	if !found {
+		return
+	}
+	found, typeInfo, groupInfo = decoders.typeDecoder.Decode(code[2:])
+	if !found {
+		return
+	}
+	return code, length, height, width, typeInfo, groupInfo, true
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/mrclmr/icm/internal/configs"
@@ -67,14 +69,22 @@ func Test_validateCmd(t *testing.T) {
 			false,
 			`
   ABC U 123456 0   20 G1  ✔
-   ↑  ↑            ↑↑  ↑
-   │  │            ││  └─ type:  some-type
-   │  │            ││     group: some-group
-   │  │            ││
-   │  │            │└─ height: some-height
-   │  │            │   width:  some-width
-   │  │            │
-   │  │            └─ length: some-length
+   ↑  ↑        ↑   ↑↑  ↑
+   │  │        │   ││  └─ type:  some-type
+   │  │        │   ││     group: some-group
+   │  │        │   ││
+   │  │        │   │└─ height: some-height
+   │  │        │   │   width:  some-width
+   │  │        │   │
+   │  │        │   └─ length: some-length
+   │  │        │
+   │  │        └─ Error-prone serial numbers:
+   │  │             ABC U 323456 0  substitution
+   │  │             ABC U 133456 0  substitution
+   │  │             ABC U 129456 0  substitution
+   │  │             ABC U 123756 0  substitution
+   │  │             ABC U 123416 0  substitution
+   │  │             ABC U 123454 0  substitution
    │  │
    │  └─ some-equip-cat-ID
    │
@@ -82,6 +92,174 @@ func Test_validateCmd(t *testing.T) {
       some-city
       some-country
 
+`,
+		},
+		{
+			"Validate container number with legacy country code, size and type",
+			[]string{"abc u 123456 0 de 20 g1"},
+			nil,
+			false,
+			`
+  ABC U 123456 0   DE   20 G1  ✔
+   ↑  ↑        ↑    ↑   ↑↑  ↑
+   │  │        │    │   ││  └─ type:  some-type
+   │  │        │    │   ││     group: some-group
+   │  │        │    │   ││
+   │  │        │    │   │└─ height: some-height
+   │  │        │    │   │   width:  some-width
+   │  │        │    │   │
+   │  │        │    │   └─ length: some-length
+   │  │        │    │
+   │  │        │    └─ some-legacy-country
+   │  │        │
+   │  │        └─ Error-prone serial numbers:
+   │  │             ABC U 323456 0  substitution
+   │  │             ABC U 133456 0  substitution
+   │  │             ABC U 129456 0  substitution
+   │  │             ABC U 123756 0  substitution
+   │  │             ABC U 123416 0  substitution
+   │  │             ABC U 123454 0  substitution
+   │  │
+   │  └─ some-equip-cat-ID
+   │
+   └─ some-company
+      some-city
+      some-country
+
+`,
+		},
+		{
+			"Validate container number with unknown legacy country code and ndjson output",
+			[]string{"ABC U 123456 0 XX"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}},
+			true,
+			`{"pattern":"container-number-country","owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"123456",` +
+				`"check-digit":0,"calculated-check-digit":0,"valid-check-digit":true,"possible-transposition-error":[],` +
+				`"error-prone-serial-numbers":[{"container-number":"ABC U 323456 0","class":"substitution","position":0},` +
+				`{"container-number":"ABC U 133456 0","class":"substitution","position":1},` +
+				`{"container-number":"ABC U 129456 0","class":"substitution","position":2},` +
+				`{"container-number":"ABC U 123756 0","class":"substitution","position":3},` +
+				`{"container-number":"ABC U 123416 0","class":"substitution","position":4},` +
+				`{"container-number":"ABC U 123454 0","class":"substitution","position":5}],` +
+				`"check-digit-10-collision":["ABC U 323456 0","ABC U 133456 0","ABC U 129456 0","ABC U 123756 0","ABC U 123416 0","ABC U 123454 0"],` +
+				`"suggestions":[],"legacy-country-code":"XX","legacy-country":null,"valid":false,` +
+				`"errors":[{"kind":"unknown-country-code","field":"country-code","offset":11}]}
+`,
+		},
+		{
+			"Validate size and type group code",
+			[]string{"22gp"},
+			nil,
+			false,
+			`
+  22 GP  ✔
+  ↑↑  ↑
+  ││  └─ type group: some-type-group
+  ││     group:      some-group
+  ││
+  │└─ height: some-height
+  │   width:  some-width
+  │
+  └─ length: some-length
+
+`,
+		},
+		{
+			"Validate size and type group code with normalized size-type code and ndjson output",
+			[]string{"22GP"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}, {configs.FlagNames.NormalizeSizeType, "true"}},
+			false,
+			`{"pattern":"size-type","length-code":"2","length-description":"some-length","height-width-code":"2",` +
+				`"height-description":"some-height","width-description":"some-width","type-code":"GP",` +
+				`"type-description":"some-type-group","group-description":"some-group","type-group-code":"GP",` +
+				`"size-type-code":"22GP","valid":true,"errors":[]}
+`,
+		},
+		{
+			"Validate equipment category ID that is not registered for owner",
+			[]string{"DEF J 123456 3"},
+			nil,
+			true,
+			`
+  DEF J 123456 3  ✘
+   ↑  ↑
+   │  └─ equipment category id is not registered for owner DEF
+   │     some-equip-cat-ID
+   │
+   └─ some-u-company
+      some-city
+      some-country
+
+`,
+		},
+		{
+			"Validate equipment category ID that is not registered for owner with ndjson output",
+			[]string{"DEF J 123456 3"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}},
+			true,
+			`{"pattern":"container-number","owner":{"code":"DEF","company":"some-u-company","city":"some-city","country":"some-country","source":"some-source"},` +
+				`"equipment-category-id":"J","equipment-category":"some-equip-cat-ID","serial-number":"123456",` +
+				`"check-digit":3,"calculated-check-digit":3,"valid-check-digit":true,"possible-transposition-error":[],` +
+				`"error-prone-serial-numbers":[],"check-digit-10-collision":[],"suggestions":[],"valid":false,` +
+				`"errors":[{"kind":"equipment-category-id-not-registered","field":"equipment-category-id","offset":3,"expected":"U"}]}
+`,
+		},
+		{
+			"Validate legacy size-type code",
+			[]string{"2210"},
+			nil,
+			false,
+			`
+  2210  ✔
+    ↑
+    └─ size-type code: 22G1
+       length: some-length
+       height: some-height
+       width:  some-width
+       type:  some-type
+       group: some-group
+
+`,
+		},
+		{
+			"Validate container number with legacy size-type code and normalized size-type code",
+			[]string{"ABC U 123456 0 2210"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}, {configs.FlagNames.NormalizeSizeType, "true"}},
+			false,
+			`{"pattern":"container-number-legacy-size-type","owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"123456",` +
+				`"check-digit":0,"calculated-check-digit":0,"valid-check-digit":true,"possible-transposition-error":[],` +
+				`"error-prone-serial-numbers":[{"container-number":"ABC U 323456 0","class":"substitution","position":0},` +
+				`{"container-number":"ABC U 133456 0","class":"substitution","position":1},` +
+				`{"container-number":"ABC U 129456 0","class":"substitution","position":2},` +
+				`{"container-number":"ABC U 123756 0","class":"substitution","position":3},` +
+				`{"container-number":"ABC U 123416 0","class":"substitution","position":4},` +
+				`{"container-number":"ABC U 123454 0","class":"substitution","position":5}],` +
+				`"check-digit-10-collision":["ABC U 323456 0","ABC U 133456 0","ABC U 129456 0","ABC U 123756 0","ABC U 123416 0","ABC U 123454 0"],` +
+				`"suggestions":[],"legacy-size-type-code":"2210","length-code":"2","length-description":"some-length",` +
+				`"height-width-code":"2","height-description":"some-height","width-description":"some-width",` +
+				`"type-code":"G1","type-description":"some-type","group-description":"some-group","type-group-code":"GP","size-type-code":"22G1","valid":true,"errors":[]}
+`,
+		},
+		{
+			"Validate size-type code with normalized size-type code and csv output",
+			[]string{"22G1"},
+			[]configOverride{{configs.FlagNames.Output, "csv"}, {configs.FlagNames.NormalizeSizeType, "true"}},
+			false,
+			`pattern;owner-code;company;city;country;owner-source;equipment-category-id;equipment-category;serial-number;check-digit;calculated-check-digit;valid-check-digit;possible-transposition-error;error-prone-serial-numbers;check-digit-10-collision;suggestions;legacy-country-code;legacy-country;length-code;length-description;height-width-code;height-description;width-description;type-code;type-description;group-description;type-group-code;size-type-code;legacy-size-type-code;error-code
+size-type;;;;;;;;;;;;;;;;;;2;some-length;2;some-height;some-width;G1;some-type;some-group;GP;22G1;;
+`,
+		},
+		{
+			"Validate unknown legacy size-type code and ndjson output",
+			[]string{"9999"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}},
+			true,
+			`{"pattern":"legacy-size-type","legacy-size-type-code":"9999","length-code":null,"length-description":null,` +
+				`"height-width-code":null,"height-description":null,"width-description":null,"type-code":null,` +
+				`"type-description":null,"group-description":null,"type-group-code":null,"valid":false,` +
+				`"errors":[{"kind":"unknown-legacy-size-type-code","field":"legacy-size-type-code","offset":0}]}
 `,
 		},
 		{
@@ -91,7 +269,15 @@ func Test_validateCmd(t *testing.T) {
 			false,
 			`
   ABC U 123456 0  ✔
-   ↑  ↑
+   ↑  ↑        ↑
+   │  │        └─ Error-prone serial numbers:
+   │  │             ABC U 323456 0  substitution
+   │  │             ABC U 133456 0  substitution
+   │  │             ABC U 129456 0  substitution
+   │  │             ABC U 123756 0  substitution
+   │  │             ABC U 123416 0  substitution
+   │  │             ABC U 123454 0  substitution
+   │  │
    │  └─ some-equip-cat-ID
    │
    └─ some-company
@@ -238,8 +424,14 @@ func Test_validateCmd(t *testing.T) {
    │    │            │   └─ length: some-length
    │    │            │
    │    │            └─ Error-prone serial numbers:
-   │    │                 ABC***U+++681034‧‧‧0
-   │    │                 ABC***U+++681340‧‧‧0
+   │    │                 ABC***U+++681034‧‧‧0  transposition
+   │    │                 ABC***U+++681340‧‧‧0  transposition
+   │    │                 ABC***U+++881304‧‧‧0  substitution
+   │    │                 ABC***U+++691304‧‧‧0  substitution
+   │    │                 ABC***U+++687304‧‧‧0  substitution
+   │    │                 ABC***U+++681604‧‧‧0  substitution
+   │    │                 ABC***U+++681374‧‧‧0  substitution
+   │    │                 ABC***U+++681302‧‧‧0  substitution
    │    │
    │    └─ some-equip-cat-ID
    │
@@ -278,6 +470,12 @@ func Test_validateCmd(t *testing.T) {
   ABC U 123123 1  ✘
    ↑  ↑        ↑
    │  │        └─ calculated check digit is 7
+   │  │           Did you mean:
+   │  │             ABC U 123123 7
+   │  │             ABC U 223123 1
+   │  │             ABC U 183123 1
+   │  │             ABC U 126123 1
+   │  │             ABC U 123823 1
    │  │
    │  └─ some-equip-cat-ID
    │
@@ -314,8 +512,14 @@ func Test_validateCmd(t *testing.T) {
   ABC U 681304 0  ✔
    ↑  ↑        ↑
    │  │        └─ Error-prone serial numbers:
-   │  │             ABC U 681034 0
-   │  │             ABC U 681340 0
+   │  │             ABC U 681034 0  transposition
+   │  │             ABC U 681340 0  transposition
+   │  │             ABC U 881304 0  substitution
+   │  │             ABC U 691304 0  substitution
+   │  │             ABC U 687304 0  substitution
+   │  │             ABC U 681604 0  substitution
+   │  │             ABC U 681374 0  substitution
+   │  │             ABC U 681302 0  substitution
    │  │
    │  └─ some-equip-cat-ID
    │
@@ -330,8 +534,140 @@ func Test_validateCmd(t *testing.T) {
 			[]string{"ABC U 681304 0"},
 			[]configOverride{{configs.FlagNames.Output, "csv"}},
 			false,
-			`owner-code;company;city;country;equipment-category-id;equipment-category;serial-number;check-digit;calculated-check-digit;valid-check-digit;possible-transposition-error
-ABC;some-company;some-city;some-country;U;some-equip-cat-ID;681304;0;0;true;ABC U 681034 0, ABC U 681340 0
+			`pattern;owner-code;company;city;country;owner-source;equipment-category-id;equipment-category;serial-number;check-digit;calculated-check-digit;valid-check-digit;possible-transposition-error;error-prone-serial-numbers;check-digit-10-collision;suggestions;legacy-country-code;legacy-country;length-code;length-description;height-width-code;height-description;width-description;type-code;type-description;group-description;type-group-code;legacy-size-type-code;error-code
+container-number;ABC;some-company;some-city;some-country;some-custom-source;U;some-equip-cat-ID;681304;0;0;true;ABC U 681034 0, ABC U 681340 0;ABC U 681034 0 (transposition), ABC U 681340 0 (transposition), ABC U 881304 0 (substitution), ABC U 691304 0 (substitution), ABC U 687304 0 (substitution), ABC U 681604 0 (substitution), ABC U 681374 0 (substitution), ABC U 681302 0 (substitution);ABC U 881304 0, ABC U 691304 0, ABC U 687304 0, ABC U 681604 0, ABC U 681374 0, ABC U 681302 0;;;;;;;;;;;;;;
+`,
+		},
+		{
+			"Validate container-number with wrong check digit and csv output",
+			[]string{"abc u 123123 1"},
+			[]configOverride{{configs.FlagNames.Output, "csv"}},
+			true,
+			`pattern;owner-code;company;city;country;owner-source;equipment-category-id;equipment-category;serial-number;check-digit;calculated-check-digit;valid-check-digit;possible-transposition-error;error-prone-serial-numbers;check-digit-10-collision;suggestions;legacy-country-code;legacy-country;length-code;length-description;height-width-code;height-description;width-description;type-code;type-description;group-description;type-group-code;legacy-size-type-code;error-code
+container-number;ABC;some-company;some-city;some-country;some-custom-source;U;some-equip-cat-ID;123123;1;7;false;;;;ABC U 123123 7, ABC U 223123 1, ABC U 183123 1, ABC U 126123 1, ABC U 123823 1;;;;;;;;;;;;;check-digit-mismatch
+`,
+		},
+		{
+			"Validate lines with different patterns and csv output",
+			[]string{"abc\nabc u 681304 0\n20 g1"},
+			nil,
+			false,
+			`pattern;owner-code;company;city;country;owner-source;equipment-category-id;equipment-category;serial-number;check-digit;calculated-check-digit;valid-check-digit;possible-transposition-error;error-prone-serial-numbers;check-digit-10-collision;suggestions;legacy-country-code;legacy-country;length-code;length-description;height-width-code;height-description;width-description;type-code;type-description;group-description;type-group-code;legacy-size-type-code;error-code
+owner;ABC;some-company;some-city;some-country;some-custom-source;;;;;;;;;;;;;;;;;;;;;;;
+container-number;ABC;some-company;some-city;some-country;some-custom-source;U;some-equip-cat-ID;681304;0;0;true;ABC U 681034 0, ABC U 681340 0;ABC U 681034 0 (transposition), ABC U 681340 0 (transposition), ABC U 881304 0 (substitution), ABC U 691304 0 (substitution), ABC U 687304 0 (substitution), ABC U 681604 0 (substitution), ABC U 681374 0 (substitution), ABC U 681302 0 (substitution);ABC U 881304 0, ABC U 691304 0, ABC U 687304 0, ABC U 681604 0, ABC U 681374 0, ABC U 681302 0;;;;;;;;;;;;;;
+size-type;;;;;;;;;;;;;;;;;;2;some-length;0;some-height;some-width;G1;some-type;some-group;GP;;
+`,
+		},
+		{
+			"Validate ABC U 681304 0 with ndjson output",
+			[]string{"ABC U 681304 0"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}},
+			false,
+			`{"pattern":"container-number","owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"681304",` +
+				`"check-digit":0,"calculated-check-digit":0,"valid-check-digit":true,` +
+				`"possible-transposition-error":["ABC U 681034 0","ABC U 681340 0"],` +
+				`"error-prone-serial-numbers":[{"container-number":"ABC U 681034 0","class":"transposition","position":3},` +
+				`{"container-number":"ABC U 681340 0","class":"transposition","position":4},` +
+				`{"container-number":"ABC U 881304 0","class":"substitution","position":0},` +
+				`{"container-number":"ABC U 691304 0","class":"substitution","position":1},` +
+				`{"container-number":"ABC U 687304 0","class":"substitution","position":2},` +
+				`{"container-number":"ABC U 681604 0","class":"substitution","position":3},` +
+				`{"container-number":"ABC U 681374 0","class":"substitution","position":4},` +
+				`{"container-number":"ABC U 681302 0","class":"substitution","position":5}],` +
+				`"check-digit-10-collision":["ABC U 881304 0","ABC U 691304 0","ABC U 687304 0","ABC U 681604 0","ABC U 681374 0","ABC U 681302 0"],` +
+				`"suggestions":[],` +
+				`"valid":true,"errors":[]}
+`,
+		},
+		{
+			"Validate ILU code with ndjson output",
+			[]string{"ABC A 681304 0"},
+			[]configOverride{{configs.FlagNames.Pattern, ilu}, {configs.FlagNames.Output, "ndjson"}},
+			false,
+			`{"pattern":"ilu","owner":{"code":"ABC","company":"some-ilu-company","city":"some-city","country":"some-country","source":"some-ilu-source"},` +
+				`"equipment-category-id":"A","equipment-category":"intermodal loading unit","serial-number":"681304",` +
+				`"check-digit":0,"calculated-check-digit":0,"valid-check-digit":true,` +
+				`"possible-transposition-error":["ABC A 681034 0","ABC A 681340 0"],` +
+				`"error-prone-serial-numbers":[{"container-number":"ABC A 681034 0","class":"transposition","position":3},` +
+				`{"container-number":"ABC A 681340 0","class":"transposition","position":4},` +
+				`{"container-number":"ABC A 881304 0","class":"substitution","position":0},` +
+				`{"container-number":"ABC A 691304 0","class":"substitution","position":1},` +
+				`{"container-number":"ABC A 687304 0","class":"substitution","position":2},` +
+				`{"container-number":"ABC A 681604 0","class":"substitution","position":3},` +
+				`{"container-number":"ABC A 681374 0","class":"substitution","position":4},` +
+				`{"container-number":"ABC A 681302 0","class":"substitution","position":5}],` +
+				`"check-digit-10-collision":["ABC A 881304 0","ABC A 691304 0","ABC A 687304 0","ABC A 681604 0","ABC A 681374 0","ABC A 681302 0"],` +
+				`"suggestions":[],"valid":true,"errors":[]}
+`,
+		},
+		{
+			"Validate ILU code with equipment category ID of container",
+			[]string{"ABC U 681304 0"},
+			[]configOverride{{configs.FlagNames.Pattern, ilu}, {configs.FlagNames.Output, "ndjson"}},
+			true,
+			`{"pattern":"ilu","owner":{"code":"ABC","company":null,"city":null,"country":null,"source":null},` +
+				`"equipment-category-id":"U","equipment-category":null,"serial-number":"681304",` +
+				`"check-digit":0,"calculated-check-digit":0,"valid-check-digit":true,` +
+				`"possible-transposition-error":["ABC U 681034 0","ABC U 681340 0"],` +
+				`"error-prone-serial-numbers":[{"container-number":"ABC U 681034 0","class":"transposition","position":3},` +
+				`{"container-number":"ABC U 681340 0","class":"transposition","position":4},` +
+				`{"container-number":"ABC U 881304 0","class":"substitution","position":0},` +
+				`{"container-number":"ABC U 691304 0","class":"substitution","position":1},` +
+				`{"container-number":"ABC U 687304 0","class":"substitution","position":2},` +
+				`{"container-number":"ABC U 681604 0","class":"substitution","position":3},` +
+				`{"container-number":"ABC U 681374 0","class":"substitution","position":4},` +
+				`{"container-number":"ABC U 681302 0","class":"substitution","position":5}],` +
+				`"check-digit-10-collision":["ABC U 881304 0","ABC U 691304 0","ABC U 687304 0","ABC U 681604 0","ABC U 681374 0","ABC U 681302 0"],` +
+				`"suggestions":[],"valid":false,` +
+				`"errors":[{"kind":"unknown-equipment-category-id","field":"equipment-category-id","offset":3,"expected":"A, B, C, D, K"}]}
+`,
+		},
+		{
+			"Validate ILU code with unregistered owner key",
+			[]string{"ABC B 681304 5"},
+			[]configOverride{{configs.FlagNames.Pattern, ilu}, {configs.FlagNames.Output, "ndjson"}},
+			true,
+			`{"pattern":"ilu","owner":{"code":"ABC","company":null,"city":null,"country":null,"source":null},` +
+				`"equipment-category-id":"B","equipment-category":null,"serial-number":"681304",` +
+				`"check-digit":5,"calculated-check-digit":5,"valid-check-digit":true,` +
+				`"possible-transposition-error":[],"error-prone-serial-numbers":[],"check-digit-10-collision":[],` +
+				`"suggestions":[],"valid":false,` +
+				`"errors":[{"kind":"owner-not-registered","field":"equipment-category-id","offset":3}]}
+`,
+		},
+		{
+			"Validate size and type with json output",
+			[]string{"20 G1"},
+			[]configOverride{{configs.FlagNames.Output, "json"}},
+			false,
+			`{
+  "pattern": "size-type",
+  "length-code": "2",
+  "length-description": "some-length",
+  "height-width-code": "0",
+  "height-description": "some-height",
+  "width-description": "some-width",
+  "type-code": "G1",
+  "type-description": "some-type",
+  "group-description": "some-group",
+  "type-group-code": "GP",
+  "valid": true,
+  "errors": []
+}
+`,
+		},
+		{
+			"Validate container-number with wrong check digit and ndjson output",
+			[]string{"abc u 123123 1"},
+			[]configOverride{{configs.FlagNames.Output, "ndjson"}},
+			true,
+			`{"pattern":"container-number","owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"123123",` +
+				`"check-digit":1,"calculated-check-digit":7,"valid-check-digit":false,` +
+				`"possible-transposition-error":[],"error-prone-serial-numbers":[],"check-digit-10-collision":[],` +
+				`"suggestions":["ABC U 123123 7","ABC U 223123 1","ABC U 183123 1","ABC U 126123 1","ABC U 123823 1"],"valid":false,` +
+				`"errors":[{"kind":"check-digit-mismatch","field":"check-digit","offset":10,"expected":"7"}]}
 `,
 		},
 	}
@@ -346,6 +682,10 @@ ABC;some-company;some-city;some-country;U;some-equip-cat-ID;681304;0;0;true;ABC
 					&dummyHeightWidthDecoder{},
 					&dummyTypeDecoder{},
 				},
+				iluOwnerDecoder: &dummyILUOwnerDecoder{},
+				countryDecoder:  &dummyCountryDecoder{},
+
+				legacySizeTypeDecoder: &dummyLegacySizeTypeDecoder{},
 			}
 
 			config, _ := configs.ReadConfig(configs.DefaultConfig())
@@ -367,3 +707,235 @@ ABC;some-company;some-city;some-country;U;some-equip-cat-ID;681304;0;0;true;ABC
 		})
 	}
 }
+
+func Test_validateCmdInputFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	manifestA := writeFile("a.csv", "id,container_no\n1,ABC U 681304 0\n2,abc\n")
+	manifestB := writeFile("b.csv", "id,container_no\n3,20G1\n")
+	other := writeFile("c.csv", "id,other\n4,abc\n")
+	colliding := writeFile("d.csv", "container_no,company\nABC U 681304 0,some-other-company\n")
+	collidingObject := writeFile("e.csv", "container_no,owner\nABC U 681304 0,some-owner\n")
+	lines := writeFile("lines.txt", "abc\n20 g1\n")
+	ocrLines := writeFile("ocr.txt", "A8C U 68I3O4 O\nA8C U 68I3O4 1\n")
+	codeco := writeFile("codeco.edi", "UNA:+.? 'UNB+UNOA:2+SENDER+RECEIVER+240101:1200+1'\n"+
+		"UNH+MSG1+CODECO:D:95B:UN'\nEQD+CN+ABCU1231231+22G1:102:5'\nUNT+3+MSG1'\nUNZ+1+1'\n")
+	x12 := writeFile("322.x12", "ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       "+
+		"*240101*1200*U*00401*000000001*0*P*>~\nGS*SO*SENDER*RECEIVER*20240101*1200*1*X*004010~\n"+
+		"ST*322*0001~\nN7*ABCU*6813040~\nN7*XYZU*681304****************0****45G1~\nSE*4*0001~\nGE*1*1~\nIEA*1*000000001~\n")
+
+	tests := []struct {
+		name       string
+		flags      map[string][]string
+		wantErr    bool
+		wantWriter string
+	}{
+		{
+			"Validate column of multiple CSV files",
+			map[string][]string{
+				"input-file": {manifestA, manifestB},
+				"column":     {"container_no"},
+				"delimiter":  {","},
+				"output":     {"ndjson"},
+			},
+			false,
+			`{"id":"1","container_no":"ABC U 681304 0","file":"` + manifestA + `","line":2,"pattern":"container-number",` +
+				`"owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"681304",` +
+				`"check-digit":0,"calculated-check-digit":0,"valid-check-digit":true,` +
+				`"possible-transposition-error":["ABC U 681034 0","ABC U 681340 0"],` +
+				`"error-prone-serial-numbers":[{"container-number":"ABC U 681034 0","class":"transposition","position":3},` +
+				`{"container-number":"ABC U 681340 0","class":"transposition","position":4},` +
+				`{"container-number":"ABC U 881304 0","class":"substitution","position":0},` +
+				`{"container-number":"ABC U 691304 0","class":"substitution","position":1},` +
+				`{"container-number":"ABC U 687304 0","class":"substitution","position":2},` +
+				`{"container-number":"ABC U 681604 0","class":"substitution","position":3},` +
+				`{"container-number":"ABC U 681374 0","class":"substitution","position":4},` +
+				`{"container-number":"ABC U 681302 0","class":"substitution","position":5}],` +
+				`"check-digit-10-collision":["ABC U 881304 0","ABC U 691304 0","ABC U 687304 0","ABC U 681604 0","ABC U 681374 0","ABC U 681302 0"],` +
+				`"suggestions":[],` +
+				`"valid":true,"errors":[]}
+{"id":"2","container_no":"abc","file":"` + manifestA + `","line":3,"pattern":"owner",` +
+				`"owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},"valid":true,"errors":[]}
+{"id":"3","container_no":"20G1","file":"` + manifestB + `","line":2,"pattern":"size-type",` +
+				`"length-code":"2","length-description":"some-length","height-width-code":"0","height-description":"some-height",` +
+				`"width-description":"some-width","type-code":"G1","type-description":"some-type","group-description":"some-group","type-group-code":"GP",` +
+				`"valid":true,"errors":[]}
+`,
+		},
+		{
+			"Validate lines of file with csv output",
+			map[string][]string{
+				"input-file": {lines},
+				"output":     {"csv"},
+				"pattern":    {"owner"},
+			},
+			true,
+			`file;line;pattern;owner-code;company;city;country;owner-source;error-code
+` + lines + `;1;owner;ABC;some-company;some-city;some-country;some-custom-source;
+` + lines + `;2;owner;;;;;;bad-length
+`,
+		},
+		{
+			"Validate lines of file with OCR correction",
+			map[string][]string{
+				"input-file":  {ocrLines},
+				"output":      {"csv"},
+				"pattern":     {"owner"},
+				"ocr-correct": {"true"},
+			},
+			true,
+			`file;line;pattern;ocr-corrections;owner-code;company;city;country;owner-source;error-code
+` + ocrLines + `;1;owner;8 -> B (position 1), I -> 1 (position 6), O -> 0 (position 8), O -> 0 (position 10);ABC;some-company;some-city;some-country;some-custom-source;
+` + ocrLines + `;2;owner;;;;;;;bad-length
+`,
+		},
+		{
+			"Validate EQD segments of EDIFACT file",
+			map[string][]string{
+				"input-file":   {codeco},
+				"input-format": {"edifact"},
+				"output":       {"ndjson"},
+			},
+			true,
+			`{"file":"` + codeco + `","message-reference":"MSG1","message-type":"CODECO","segment-number":2,` +
+				`"pattern":"container-number-size-type",` +
+				`"owner":{"code":"ABC","company":"some-company","city":"some-city","country":"some-country","source":"some-custom-source"},` +
+				`"equipment-category-id":"U","equipment-category":"some-equip-cat-ID","serial-number":"123123",` +
+				`"check-digit":1,"calculated-check-digit":7,"valid-check-digit":false,` +
+				`"possible-transposition-error":[],"error-prone-serial-numbers":[],"check-digit-10-collision":[],` +
+				`"suggestions":["ABC U 123123 7","ABC U 223123 1","ABC U 183123 1","ABC U 126123 1","ABC U 123823 1"],` +
+				`"length-code":"2","length-description":"some-length","height-width-code":"2","height-description":"some-height",` +
+				`"width-description":"some-width","type-code":"G1","type-description":"some-type","group-description":"some-group","type-group-code":"GP",` +
+				`"valid":false,"errors":[{"kind":"check-digit-mismatch","field":"check-digit","offset":10,"expected":"7"}]}
+`,
+		},
+		{
+			"Validate N7 segments of X12 file",
+			map[string][]string{
+				"input-file":   {x12},
+				"input-format": {"x12"},
+				"output":       {"csv"},
+			},
+			true,
+			`file;transaction-set;control-number;segment-number;equipment-type;pattern;owner-code;company;city;country;owner-source;` +
+				`equipment-category-id;equipment-category;serial-number;check-digit;calculated-check-digit;valid-check-digit;` +
+				`possible-transposition-error;error-prone-serial-numbers;check-digit-10-collision;suggestions;error-code
+` + x12 + `;322;0001;2;;container-number;ABC;some-company;some-city;some-country;some-custom-source;U;some-equip-cat-ID;681304;0;0;true;` +
+				`ABC U 681034 0, ABC U 681340 0;` +
+				`ABC U 681034 0 (transposition), ABC U 681340 0 (transposition), ABC U 881304 0 (substitution), ABC U 691304 0 (substitution), ABC U 687304 0 (substitution), ABC U 681604 0 (substitution), ABC U 681374 0 (substitution), ABC U 681302 0 (substitution);` +
+				`ABC U 881304 0, ABC U 691304 0, ABC U 687304 0, ABC U 681604 0, ABC U 681374 0, ABC U 681302 0;;
+` + x12 + `;322;0001;3;45G1;container-number;;;;;;U;some-equip-cat-ID;681304;0;0;true;` +
+				`XYZ U 681034 0, XYZ U 681340 0;` +
+				`XYZ U 681034 0 (transposition), XYZ U 681340 0 (transposition), XYZ U 881304 0 (substitution), XYZ U 691304 0 (substitution), XYZ U 687304 0 (substitution), XYZ U 681604 0 (substitution), XYZ U 681374 0 (substitution), XYZ U 681302 0 (substitution);` +
+				`XYZ U 881304 0, XYZ U 691304 0, XYZ U 687304 0, XYZ U 681604 0, XYZ U 681374 0, XYZ U 681302 0;;owner-not-registered
+`,
+		},
+		{
+			"Validate EDIFACT file with column",
+			map[string][]string{
+				"input-file":   {codeco},
+				"input-format": {"edifact"},
+				"column":       {"container_no"},
+			},
+			true,
+			"",
+		},
+		{
+			"Validate CSV files with different headers",
+			map[string][]string{
+				"input-file": {manifestA, other},
+				"column":     {"container_no"},
+				"delimiter":  {","},
+				"output":     {"csv"},
+			},
+			true,
+			`id,container_no,file,line,pattern,owner-code,company,city,country,owner-source,equipment-category-id,equipment-category,` +
+				`serial-number,check-digit,calculated-check-digit,valid-check-digit,possible-transposition-error,error-prone-serial-numbers,check-digit-10-collision,suggestions,legacy-country-code,legacy-country,length-code,` +
+				`length-description,height-width-code,height-description,width-description,type-code,type-description,` +
+				`group-description,type-group-code,legacy-size-type-code,error-code
+1,ABC U 681304 0,` + manifestA + `,2,container-number,ABC,some-company,some-city,some-country,some-custom-source,U,some-equip-cat-ID,` +
+				`681304,0,0,true,"ABC U 681034 0, ABC U 681340 0",` +
+				`"ABC U 681034 0 (transposition), ABC U 681340 0 (transposition), ABC U 881304 0 (substitution), ABC U 691304 0 (substitution), ABC U 687304 0 (substitution), ABC U 681604 0 (substitution), ABC U 681374 0 (substitution), ABC U 681302 0 (substitution)",` +
+				`"ABC U 881304 0, ABC U 691304 0, ABC U 687304 0, ABC U 681604 0, ABC U 681374 0, ABC U 681302 0",,,,,,,,,,,,,,
+2,abc,` + manifestA + `,3,owner,ABC,some-company,some-city,some-country,some-custom-source,,,,,,,,,,,,,,,,,,,,,,,
+`,
+		},
+		{
+			"Validate CSV file with a column named like a validation column",
+			map[string][]string{
+				"input-file": {colliding},
+				"column":     {"container_no"},
+				"delimiter":  {","},
+			},
+			true,
+			"",
+		},
+		{
+			"Validate CSV file with a column named like a JSON object",
+			map[string][]string{
+				"input-file": {collidingObject},
+				"column":     {"container_no"},
+				"delimiter":  {","},
+				"output":     {"ndjson"},
+			},
+			true,
+			"",
+		},
+		{
+			"Validate column that does not exist",
+			map[string][]string{
+				"input-file": {manifestA},
+				"column":     {"unknown"},
+				"delimiter":  {","},
+			},
+			true,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			d := decoders{
+				ownerDecodeUpdater: &dummyOwnerDecodeUpdater{},
+				equipCatDecoder:    &dummyEquipCatDecoder{},
+				sizeTypeDecoders: sizeTypeDecoders{
+					&dummyLengthDecoder{},
+					&dummyHeightWidthDecoder{},
+					&dummyTypeDecoder{},
+				},
+				iluOwnerDecoder: &dummyILUOwnerDecoder{},
+				countryDecoder:  &dummyCountryDecoder{},
+
+				legacySizeTypeDecoder: &dummyLegacySizeTypeDecoder{},
+			}
+
+			config, _ := configs.ReadConfig(configs.DefaultConfig())
+
+			cmd, err := newValidateCmd(nil, writer, config, d)
+			if err != nil {
+				t.Errorf("newValidateCmd: %v", err)
+			}
+			for name, values := range tt.flags {
+				for _, value := range values {
+					if err := cmd.Flags().Set(name, value); err != nil {
+						t.Fatalf("Set(%s, %s): %v", name, value, err)
+					}
+				}
+			}
+
+			if got := cmd.RunE(cmd, nil); (got != nil) != tt.wantErr {
+				t.Errorf("got = %v, wantErr is %v", got, tt.wantErr)
+			}
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
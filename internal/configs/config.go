@@ -3,6 +3,7 @@ package configs
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/pflag"
 	"go.yaml.in/yaml/v3"
@@ -11,25 +12,29 @@ import (
 // Config represents the configuration.
 type Config struct {
 	Map map[string]string
+
+	ownerSources []string
 }
 
 // Overwrite overwrites the configuration with command line flags.
 func (c *Config) Overwrite(flagSet *pflag.FlagSet) {
 	for k := range map[string]bool{
-		FlagNames.Pattern:  true,
-		FlagNames.NoHeader: true,
-		FlagNames.Output:   true,
-		FlagNames.SepOE:    true,
-		FlagNames.SepES:    true,
-		FlagNames.SepSC:    true,
-		FlagNames.SepCS:    true,
-		FlagNames.SepST:    true,
+		FlagNames.Pattern:           true,
+		FlagNames.NoHeader:          true,
+		FlagNames.Output:            true,
+		FlagNames.Delimiter:         true,
+		FlagNames.SepOE:             true,
+		FlagNames.SepES:             true,
+		FlagNames.SepSC:             true,
+		FlagNames.SepCS:             true,
+		FlagNames.SepST:             true,
+		FlagNames.NormalizeSizeType: true,
 	} {
 
 		_, exists := c.Map[k]
 
 		if flagSet.Changed(k) || !exists {
-			if k == FlagNames.NoHeader {
+			if k == FlagNames.NoHeader || k == FlagNames.NormalizeSizeType {
 				value, _ := flagSet.GetBool(k)
 				c.Map[k] = fmt.Sprintf("%t", value)
 				continue
@@ -51,11 +56,22 @@ func (c *Config) NoHeader() bool {
 	return value
 }
 
+// NormalizeSizeType returns normalize size type config.
+func (c *Config) NormalizeSizeType() bool {
+	value, _ := strconv.ParseBool(c.Map[FlagNames.NormalizeSizeType])
+	return value
+}
+
 // Output returns output config.
 func (c *Config) Output() string {
 	return c.Map[FlagNames.Output]
 }
 
+// Delimiter returns the delimiter of CSV input and output.
+func (c *Config) Delimiter() string {
+	return c.Map[FlagNames.Delimiter]
+}
+
 // SepOE returns the separator between owner and equipment category.
 func (c *Config) SepOE() string {
 	return c.Map[FlagNames.SepOE]
@@ -81,19 +97,57 @@ func (c *Config) SepST() string {
 	return c.Map[FlagNames.SepST]
 }
 
+// OwnerSources returns the owner sources from lowest to highest priority.
+func (c *Config) OwnerSources() []string {
+	// Config files of older versions have no owner sources config.
+	if c.ownerSources == nil {
+		return DefaultValues.OwnerSources
+	}
+	return c.ownerSources
+}
+
 // ReadConfig returns the read config.
 func ReadConfig(b []byte) (*Config, error) {
 	c := Config{
-		make(map[string]string),
+		Map: make(map[string]string),
 	}
-	err := yaml.Unmarshal(b, &c.Map)
+	var nodes map[string]yaml.Node
+	err := yaml.Unmarshal(b, &nodes)
 	if err != nil {
 		return nil, err
 	}
+	for k, node := range nodes {
+		if k == FlagNames.OwnerSources {
+			if err := node.Decode(&c.ownerSources); err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			if len(c.ownerSources) == 0 {
+				return nil, fmt.Errorf("%s: no owner source", k)
+			}
+			for _, source := range c.ownerSources {
+				if source == "" {
+					return nil, fmt.Errorf("%s: owner source is empty", k)
+				}
+			}
+			continue
+		}
+		var value string
+		if err := node.Decode(&value); err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		c.Map[k] = value
+	}
 	_, err = strconv.ParseBool(c.Map[FlagNames.NoHeader])
 	if err != nil {
 		return nil, err
 	}
+	// Config files of older versions have no normalize size type config.
+	if value, exists := c.Map[FlagNames.NormalizeSizeType]; exists {
+		_, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &c, nil
 }
@@ -106,71 +160,101 @@ const (
 
 // Names is the structure for the flag names.
 type Names struct {
-	Pattern  string
-	NoHeader string
-	Output   string
-	SepOE    string
-	SepES    string
-	SepSC    string
-	SepCS    string
-	SepST    string
+	Pattern           string
+	NoHeader          string
+	Output            string
+	Delimiter         string
+	SepOE             string
+	SepES             string
+	SepSC             string
+	SepCS             string
+	SepST             string
+	NormalizeSizeType string
+	OwnerSources      string
 }
 
 // FlagNames has all the flag names.
 var FlagNames = Names{
-	Pattern:  "pattern",
-	NoHeader: "no-header",
-	Output:   "output",
-	SepOE:    "sep-owner-equip",
-	SepES:    "sep-equip-serial",
-	SepSC:    "sep-serial-check",
-	SepCS:    "sep-check-size",
-	SepST:    "sep-size-type",
+	Pattern:           "pattern",
+	NoHeader:          "no-header",
+	Output:            "output",
+	Delimiter:         "delimiter",
+	SepOE:             "sep-owner-equip",
+	SepES:             "sep-equip-serial",
+	SepSC:             "sep-serial-check",
+	SepCS:             "sep-check-size",
+	SepST:             "sep-size-type",
+	NormalizeSizeType: "normalize-size-type",
+	OwnerSources:      "owner-sources",
 }
 
 // Values is the structure for the default flag values.
 type Values struct {
-	Pattern  string
-	NoHeader bool
-	Output   string
-	SepOE    string
-	SepES    string
-	SepSC    string
-	SepCS    string
-	SepST    string
+	Pattern           string
+	NoHeader          bool
+	Output            string
+	Delimiter         string
+	SepOE             string
+	SepES             string
+	SepSC             string
+	SepCS             string
+	SepST             string
+	NormalizeSizeType bool
+	OwnerSources      []string
 }
 
 // DefaultValues has all the default values.
 var DefaultValues = Values{
-	Pattern:  "auto",
-	NoHeader: false,
-	Output:   "auto",
-	SepOE:    " ",
-	SepES:    " ",
-	SepSC:    " ",
-	SepCS:    "   ",
-	SepST:    " ",
+	Pattern:           "auto",
+	NoHeader:          false,
+	Output:            "auto",
+	Delimiter:         ";",
+	SepOE:             " ",
+	SepES:             " ",
+	SepSC:             " ",
+	SepCS:             "   ",
+	SepST:             " ",
+	NormalizeSizeType: false,
+	OwnerSources:      []string{"owner.csv", "custom-owner.csv"},
 }
 
 // DefaultConfig returns default config.
 func DefaultConfig() []byte {
 	return []byte(`# Pattern matching mode
-#                     auto = matches automatically a pattern
+#                     auto = matches automatically a pattern per line
 #         container-number = matches a container number
 #                    owner = matches a three letter owner code
 # owner-equipment-category = matches a three letter owner code with equipment category ID
 #                size-type = matches length, width+height and type code
+#                      ilu = matches an intermodal loading unit code (EN 13044)
+#         legacy-size-type = matches a four digit size-type code of ISO 6346:1984
 ` + FlagNames.Pattern + `: ` + DefaultValues.Pattern + `
 
 # Output mode
-#  auto = for a single line 'fancy' and for multiple lines 'csv' output 
-#   csv = machine readable CSV output
-# fancy = human readable fancy output
+#   auto = for a single line 'fancy' and for multiple lines 'csv' output 
+#    csv = machine readable CSV output
+#  fancy = human readable fancy output
+#   json = machine readable indented JSON object per line
+# ndjson = machine readable JSON object per line (newline delimited JSON)
 ` + FlagNames.Output + `: ` + DefaultValues.Output + `
 
 # No header for CSV output
 ` + FlagNames.NoHeader + `: ` + fmt.Sprintf("%t", DefaultValues.NoHeader) + `
 
+# Size-type code of ISO 6346:1995 as column size-type-code for current and legacy codes like 2210
+` + FlagNames.NormalizeSizeType + `: ` + fmt.Sprintf("%t", DefaultValues.NormalizeSizeType) + `
+
+# Owner sources from lowest to highest priority
+# The owner of an owner code is taken from the last source that has the owner code.
+#   embedded = read-only owners that are shipped with icm
+#       file = owner CSV file like owner.csv
+#  directory = every owner CSV file with extension .csv of the directory in lexical order
+# Relative paths are relative to $HOME/.icm/data. Missing files and directories are skipped.
+` + FlagNames.OwnerSources + `:
+` + ownerSourcesYAML(DefaultValues.OwnerSources) + `
+# Delimiter for CSV input and output
+` + FlagNames.Delimiter + `: '` + DefaultValues.Delimiter + `'
+
 #  Separators
 #
 #  ABC U 123456 0   20 G1
@@ -192,3 +276,11 @@ func DefaultConfig() []byte {
 ` + FlagNames.SepST + `:    '` + DefaultValues.SepST + `'
 `)
 }
+
+func ownerSourcesYAML(sources []string) string {
+	var b strings.Builder
+	for _, source := range sources {
+		b.WriteString("  - " + source + "\n")
+	}
+	return b.String()
+}
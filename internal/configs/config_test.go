@@ -19,17 +19,46 @@ func TestReadConfig(t *testing.T) {
 			"parse default config",
 			DefaultConfig(),
 			&Config{Map: map[string]string{
-				FlagNames.Pattern:  DefaultValues.Pattern,
-				FlagNames.NoHeader: fmt.Sprintf("%t", DefaultValues.NoHeader),
-				FlagNames.Output:   DefaultValues.Output,
-				FlagNames.SepOE:    DefaultValues.SepOE,
-				FlagNames.SepES:    DefaultValues.SepES,
-				FlagNames.SepSC:    DefaultValues.SepSC,
-				FlagNames.SepCS:    DefaultValues.SepCS,
-				FlagNames.SepST:    DefaultValues.SepST,
-			}},
+				FlagNames.Pattern:           DefaultValues.Pattern,
+				FlagNames.NoHeader:          fmt.Sprintf("%t", DefaultValues.NoHeader),
+				FlagNames.Output:            DefaultValues.Output,
+				FlagNames.Delimiter:         DefaultValues.Delimiter,
+				FlagNames.SepOE:             DefaultValues.SepOE,
+				FlagNames.SepES:             DefaultValues.SepES,
+				FlagNames.SepSC:             DefaultValues.SepSC,
+				FlagNames.SepCS:             DefaultValues.SepCS,
+				FlagNames.SepST:             DefaultValues.SepST,
+				FlagNames.NormalizeSizeType: fmt.Sprintf("%t", DefaultValues.NormalizeSizeType),
+			}, ownerSources: DefaultValues.OwnerSources},
 			false,
 		},
+		{
+			"parse owner sources",
+			[]byte("no-header: false\nowner-sources:\n  - embedded\n  - /etc/icm/owners\n"),
+			&Config{
+				Map:          map[string]string{FlagNames.NoHeader: "false"},
+				ownerSources: []string{"embedded", "/etc/icm/owners"},
+			},
+			false,
+		},
+		{
+			"owner sources that are not a list return error",
+			[]byte("no-header: false\nowner-sources: owner.csv\n"),
+			nil,
+			true,
+		},
+		{
+			"empty owner sources return error",
+			[]byte("no-header: false\nowner-sources: []\n"),
+			nil,
+			true,
+		},
+		{
+			"empty owner source returns error",
+			[]byte("no-header: false\nowner-sources:\n  - ''\n"),
+			nil,
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -82,3 +111,13 @@ func TestConfig_OverwriteBool(t *testing.T) {
 		t.Fatalf("ReadConfig() got = %v, want true", c.NoHeader())
 	}
 }
+
+func TestConfig_OwnerSources(t *testing.T) {
+	c, err := ReadConfig([]byte("no-header: false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c.OwnerSources(), DefaultValues.OwnerSources) {
+		t.Errorf("OwnerSources() got = %v, want %v", c.OwnerSources(), DefaultValues.OwnerSources)
+	}
+}
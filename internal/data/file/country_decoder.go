@@ -0,0 +1,51 @@
+package file
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+const countryFileName = "country.json"
+
+//go:embed country.json
+var countryJSON []byte
+
+// NewCountryDecoder writes legacy country code file to path if it not exists and
+// returns a struct that uses this file as a data source.
+func NewCountryDecoder(path string) (*CountryDecoder, error) {
+	country := &CountryDecoder{}
+	pathToCountry := filepath.Join(path, countryFileName)
+	if err := initFile(pathToCountry, countryJSON); err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(pathToCountry)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &country.countries); err != nil {
+		return nil, err
+	}
+	for code := range country.countries {
+		if err := iso6346.IsCountryCode(code); err != nil {
+			return nil, err
+		}
+	}
+	return country, nil
+}
+
+// CountryDecoder holds the legacy country codes for decoding.
+type CountryDecoder struct {
+	countries map[string]string
+}
+
+// Decode decodes a legacy country code to a country.
+func (cd *CountryDecoder) Decode(code string) (bool, iso6346.Country) {
+	if val, ok := cd.countries[code]; ok {
+		return true, iso6346.Country{Code: code, Name: val}
+	}
+	return false, iso6346.Country{}
+}
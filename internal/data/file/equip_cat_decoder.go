@@ -6,7 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 const equipCatIDsFileName = "equipment-category-id.json"
@@ -30,7 +30,7 @@ func NewEquipCatDecoder(path string) (*EquipCatDecoder, error) {
 		return nil, err
 	}
 	for ID := range equipCat.categories {
-		if err := cont.IsEquipCatID(ID); err != nil {
+		if err := iso6346.IsEquipCatID(ID); err != nil {
 			return nil, err
 		}
 	}
@@ -42,11 +42,11 @@ type EquipCatDecoder struct {
 }
 
 // Decode decodes ID to equipment category ID.
-func (ecd *EquipCatDecoder) Decode(ID string) (bool, cont.EquipCat) {
+func (ecd *EquipCatDecoder) Decode(ID string) (bool, iso6346.EquipCat) {
 	if val, ok := ecd.categories[ID]; ok {
-		return true, cont.NewEquipCatID(ID, val)
+		return true, iso6346.NewEquipCatID(ID, val)
 	}
-	return false, cont.EquipCat{}
+	return false, iso6346.EquipCat{}
 }
 
 // AllCatIDs returns all equipment category IDs.
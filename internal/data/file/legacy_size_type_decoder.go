@@ -0,0 +1,87 @@
+package file
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+const legacySizeTypeFileName = "legacy-size-type.json"
+
+//go:embed legacy-size-type.json
+var legacySizeTypeJSON []byte
+
+// legacySizeType converts the size (first two digits) and the type (last two digits)
+// of a legacy size and type code separately to current codes.
+type legacySizeType struct {
+	Size map[string]string `json:"size"`
+	Type map[string]string `json:"type"`
+}
+
+// NewLegacySizeTypeDecoder writes legacy size and type conversion file to path if it not exists and
+// returns a struct that uses this file as a data source.
+func NewLegacySizeTypeDecoder(path string) (*LegacySizeTypeDecoder, error) {
+	pathToLegacySizeType := filepath.Join(path, legacySizeTypeFileName)
+	if err := initFile(pathToLegacySizeType, legacySizeTypeJSON); err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(pathToLegacySizeType)
+	if err != nil {
+		return nil, err
+	}
+
+	var l legacySizeType
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, err
+	}
+	for legacySize, size := range l.Size {
+		if !isTwoDigits(legacySize) {
+			return nil, fmt.Errorf("%s: legacy size %s is not 2 digits", pathToLegacySizeType, legacySize)
+		}
+		if len(size) != 2 {
+			return nil, fmt.Errorf("%s: size %s is not 2 characters long", pathToLegacySizeType, size)
+		}
+		if err := iso6346.IsLengthCode(size[:1]); err != nil {
+			return nil, err
+		}
+		if err := iso6346.IsHeightWidthCode(size[1:]); err != nil {
+			return nil, err
+		}
+	}
+	for legacyType, typeCode := range l.Type {
+		if !isTwoDigits(legacyType) {
+			return nil, fmt.Errorf("%s: legacy type %s is not 2 digits", pathToLegacySizeType, legacyType)
+		}
+		if err := iso6346.IsTypeCode(typeCode); err != nil {
+			return nil, err
+		}
+	}
+	return &LegacySizeTypeDecoder{l.Size, l.Type}, nil
+}
+
+// LegacySizeTypeDecoder holds the conversion of legacy size and type codes.
+type LegacySizeTypeDecoder struct {
+	sizes map[string]string
+	types map[string]string
+}
+
+// Decode returns the current size and type code of a legacy size and type code, e.g. 22G1 for 2210.
+func (lsd *LegacySizeTypeDecoder) Decode(code string) (bool, string) {
+	if iso6346.IsLegacySizeTypeCode(code) != nil {
+		return false, ""
+	}
+	size, sizeFound := lsd.sizes[code[:2]]
+	typeCode, typeFound := lsd.types[code[2:]]
+	if !sizeFound || !typeFound {
+		return false, ""
+	}
+	return true, size + typeCode
+}
+
+func isTwoDigits(s string) bool {
+	return len(s) == 2 && s[0] >= '0' && s[0] <= '9' && s[1] >= '0' && s[1] <= '9'
+}
@@ -0,0 +1,31 @@
+package file
+
+import "testing"
+
+func TestLegacySizeTypeDecoder_Decode(t *testing.T) {
+	decoder, err := NewLegacySizeTypeDecoder(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLegacySizeTypeDecoder() error = %v", err)
+	}
+	tests := []struct {
+		name      string
+		code      string
+		wantFound bool
+		want      string
+	}{
+		{"General purpose container", "2210", true, "22G1"},
+		{"High cube general purpose container", "4510", true, "45G1"},
+		{"Refrigerated container", "2232", true, "22R1"},
+		{"40 feet container with legacy size 43", "4310", true, "42G1"},
+		{"Unknown type", "2299", false, ""},
+		{"Current code", "22G1", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, got := decoder.Decode(tt.code)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("Decode() got = %v, %v, want %v, %v", found, got, tt.wantFound, tt.want)
+			}
+		})
+	}
+}
@@ -1,86 +1,174 @@
 package file
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
-	"maps"
 	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/internal/data"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 const (
-	csvSep             = ';'
+	csvSep = ';'
+	// csvFieldsPerRecord is the count of fields of owner files without registered equipment category IDs.
 	csvFieldsPerRecord = 4
+	// csvFieldsPerRecordWithEquipCatIDs is the count of fields of owner files with registered
+	// equipment category IDs, e.g. ABC;company;city;country;UJ.
+	csvFieldsPerRecordWithEquipCatIDs = 5
 )
 
 //go:embed owner.csv
 var ownerCSV []byte
 
+//go:embed ilu-owner.csv
+var iluOwnerCSV []byte
+
+// OwnerSourceEmbedded is the owner source of the read-only owners that are shipped with the program.
+const OwnerSourceEmbedded = "embedded"
+
 type owner struct {
-	Company string
-	City    string
-	Country string
+	Source      string
+	Company     string
+	City        string
+	Country     string
+	EquipCatIDs string
 }
 
 type OwnerDecoder struct {
-	owners map[string]owner
+	// owners has the owners of every owner source from lowest to highest priority.
+	owners map[string][]owner
 }
 
 // NewOwnerDecoder writes owner file to path if it not exists and
-// returns a struct that uses this file as a data source.
-func NewOwnerDecoder(remoteOwnersPath, customOwnersPath string) (*OwnerDecoder, error) {
-	decoder := &OwnerDecoder{}
-
+// returns a struct that uses the owner sources from lowest to highest priority.
+// An owner source is OwnerSourceEmbedded, an owner file or a directory of owner files.
+// Relative paths of owner sources are relative to the directory of the owner file.
+func NewOwnerDecoder(remoteOwnersPath string, sources []string) (*OwnerDecoder, error) {
 	if err := initFile(remoteOwnersPath, ownerCSV); err != nil {
 		return nil, err
 	}
-
-	ownersMap, err := readFile(remoteOwnersPath)
+	resolved := ResolveOwnerSources(remoteOwnersPath, sources)
+	decoder, err := newOwnerDecoder(resolved, ownerCSV, iso6346.IsOwnerCode)
 	if err != nil {
 		return nil, err
 	}
+	if len(decoder.owners) == 0 {
+		return nil, fmt.Errorf("%v: no owners found", strings.Join(resolved, ", "))
+	}
+	return decoder, nil
+}
 
-	if len(ownersMap) == 0 {
-		return nil, fmt.Errorf("%v: no owners found", remoteOwnersPath)
+// ResolveOwnerSources returns the owner sources with paths that are relative
+// to the directory of the owner file in remoteOwnersPath.
+func ResolveOwnerSources(remoteOwnersPath string, sources []string) []string {
+	resolved := make([]string, len(sources))
+	for i, source := range sources {
+		resolved[i] = source
+		if source != OwnerSourceEmbedded && !filepath.IsAbs(source) {
+			resolved[i] = filepath.Join(filepath.Dir(remoteOwnersPath), source)
+		}
 	}
+	return resolved
+}
 
-	if _, err := os.Stat(customOwnersPath); err == nil {
+// NewILUOwnerDecoder writes ILU owner file to path if it not exists and
+// returns a struct that uses this file as a data source.
+// The owners are decoded by owner keys of intermodal loading units (ILU), e.g. ABCA.
+// The shipped ILU owner file is empty because there is no public ILU owner registry,
+// so owner keys are only registered by the custom ILU owner file.
+func NewILUOwnerDecoder(remoteOwnersPath, customOwnersPath string) (*OwnerDecoder, error) {
+	if err := initFile(remoteOwnersPath, iluOwnerCSV); err != nil {
+		return nil, err
+	}
+	return newOwnerDecoder([]string{remoteOwnersPath, customOwnersPath}, iluOwnerCSV, iso6346.IsILUOwnerKey)
+}
 
-		customOwnersMap, err := readFile(customOwnersPath)
+func newOwnerDecoder(sources []string, content []byte, isCode func(string) error) (*OwnerDecoder, error) {
+	decoder := &OwnerDecoder{owners: make(map[string][]owner)}
+
+	for _, source := range sources {
+		paths, err := sourcePaths(source)
 		if err != nil {
 			return nil, err
 		}
-
-		maps.Copy(ownersMap, customOwnersMap)
+		for _, path := range paths {
+			var ownersMap map[string]owner
+			if path == OwnerSourceEmbedded {
+				ownersMap, err = readCSV(bytes.NewReader(content), isCode)
+			} else {
+				ownersMap, err = readFile(path, isCode)
+			}
+			if err != nil {
+				return nil, err
+			}
+			for code, o := range ownersMap {
+				o.Source = path
+				decoder.owners[code] = append(decoder.owners[code], o)
+			}
+		}
 	}
 
-	decoder.owners = ownersMap
-
 	return decoder, nil
 }
 
-func readFile(path string) (map[string]owner, error) {
+// sourcePaths returns the owner files of an owner source. The owner files of
+// a directory are returned in lexical order. A missing owner source has no owner files.
+func sourcePaths(source string) ([]string, error) {
+	if source == OwnerSourceEmbedded {
+		return []string{source}, nil
+	}
+	info, err := os.Stat(source)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{source}, nil
+	}
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".csv" {
+			paths = append(paths, filepath.Join(source, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+func readFile(path string, isCode func(string) error) (map[string]owner, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = f.Close()
+	}()
 
-	ownersMap, err := readCSV(f)
+	ownersMap, err := readCSV(f, isCode)
 	if err != nil {
 		return nil, fmt.Errorf("%v: %w", path, err)
 	}
 	return ownersMap, nil
 }
 
-func readCSV(r io.Reader) (map[string]owner, error) {
+func readCSV(r io.Reader, isCode func(string) error) (map[string]owner, error) {
 	csvReader := csv.NewReader(r)
 
 	csvReader.Comma = csvSep
-	csvReader.FieldsPerRecord = csvFieldsPerRecord
+	// Owner files of previous versions have no registered equipment category IDs.
+	csvReader.FieldsPerRecord = -1
 
 	ownersMap := make(map[string]owner)
 
@@ -94,33 +182,64 @@ func readCSV(r io.Reader) (map[string]owner, error) {
 			return nil, err
 		}
 
+		if len(rec) != csvFieldsPerRecord && len(rec) != csvFieldsPerRecordWithEquipCatIDs {
+			line, _ := csvReader.FieldPos(0)
+			return nil, fmt.Errorf("record on line %d: wrong number of fields", line)
+		}
+
 		ownerCode := rec[0]
 
-		if err := cont.IsOwnerCode(ownerCode); err != nil {
+		if err := isCode(ownerCode); err != nil {
 			return nil, err
 		}
 
+		var equipCatIDs string
+		if len(rec) == csvFieldsPerRecordWithEquipCatIDs {
+			equipCatIDs = rec[4]
+			for _, ID := range equipCatIDs {
+				if err := iso6346.IsEquipCatID(string(ID)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		ownersMap[ownerCode] = owner{
-			Company: rec[1],
-			City:    rec[2],
-			Country: rec[3],
+			Company:     rec[1],
+			City:        rec[2],
+			Country:     rec[3],
+			EquipCatIDs: equipCatIDs,
 		}
 	}
 
 	return ownersMap, nil
 }
 
-// Decode returns an owner for an owner code.
-func (od *OwnerDecoder) Decode(code string) (bool, cont.Owner) {
-	if val, ok := od.owners[code]; ok {
-		return true, cont.Owner{
-			Code:    code,
-			Company: val.Company,
-			City:    val.City,
-			Country: val.Country,
-		}
+// Decode returns an owner for an owner code of the owner source with the highest priority.
+func (od *OwnerDecoder) Decode(code string) (bool, iso6346.Owner) {
+	if vals, ok := od.owners[code]; ok {
+		return true, vals[len(vals)-1].toOwner(code)
+	}
+	return false, iso6346.Owner{}
+}
+
+// DecodeSources returns the owners for an owner code of every owner source
+// from lowest to highest priority.
+func (od *OwnerDecoder) DecodeSources(code string) []data.SourcedOwner {
+	var owners []data.SourcedOwner
+	for _, val := range od.owners[code] {
+		owners = append(owners, data.SourcedOwner{Source: val.Source, Owner: val.toOwner(code)})
+	}
+	return owners
+}
+
+func (o owner) toOwner(code string) iso6346.Owner {
+	return iso6346.Owner{
+		Code:        code,
+		Company:     o.Company,
+		City:        o.City,
+		Country:     o.Country,
+		EquipCatIDs: o.EquipCatIDs,
 	}
-	return false, cont.Owner{}
 }
 
 // GetAllOwnerCodes returns a count of owner codes.
@@ -5,20 +5,31 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
 )
 
 func TestNewOwnerDecoder(t *testing.T) {
-	want := &OwnerDecoder{
-		owners: map[string]owner{
-			"AAA": {Company: "my company", City: "my city", Country: "my country"},
-			"CUS": {Company: "my custom company", City: "my custom city", Country: "my custom country"},
-		},
-	}
-
 	dir := t.TempDir()
 	remotePath := filepath.Join(dir, "remote-owners.csv")
 	customPath := filepath.Join(dir, "custom-owners.csv")
+	customDir := filepath.Join(dir, "custom")
+
+	want := &OwnerDecoder{
+		owners: map[string][]owner{
+			"AAA": {
+				{Source: OwnerSourceEmbedded, Company: "my company", City: "my city", Country: "my country"},
+				{Source: remotePath, Company: "my company", City: "my city", Country: "my country"},
+				{Source: filepath.Join(customDir, "b.csv"), Company: "my private company", City: "my city", Country: "my country"},
+			},
+			"CUS": {
+				{Source: customPath, Company: "my custom company", City: "my custom city", Country: "my custom country"},
+				{Source: filepath.Join(customDir, "a.csv"), Company: "my other company", City: "my other city", Country: "my other country", EquipCatIDs: "U"},
+			},
+		},
+	}
 
 	if _, err := os.Stat(remotePath); err == nil {
 		t.Errorf("NewOwnerDecoder() file should not exist: %s", remotePath)
@@ -26,8 +37,12 @@ func TestNewOwnerDecoder(t *testing.T) {
 	}
 
 	_ = os.WriteFile(customPath, []byte("CUS;my custom company;my custom city;my custom country"), 0o644)
+	_ = os.Mkdir(customDir, 0o755)
+	_ = os.WriteFile(filepath.Join(customDir, "a.csv"), []byte("CUS;my other company;my other city;my other country;U"), 0o644)
+	_ = os.WriteFile(filepath.Join(customDir, "b.csv"), []byte("AAA;my private company;my city;my country"), 0o644)
+	_ = os.WriteFile(filepath.Join(customDir, "notes.txt"), []byte("no owners"), 0o644)
 
-	got, err := NewOwnerDecoder(remotePath, customPath)
+	got, err := NewOwnerDecoder(remotePath, []string{OwnerSourceEmbedded, "remote-owners.csv", customPath, "custom", "missing.csv"})
 	if err != nil {
 		t.Errorf("NewOwnerDecoder() error = %v, want no err", err)
 		return
@@ -39,4 +54,105 @@ func TestNewOwnerDecoder(t *testing.T) {
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("NewOwnerDecoder() got = %v, want %v", got, want)
 	}
+
+	found, gotOwner := got.Decode("AAA")
+	wantOwner := iso6346.Owner{Code: "AAA", Company: "my private company", City: "my city", Country: "my country"}
+	if !found || gotOwner != wantOwner {
+		t.Errorf("Decode() got = %v, want %v", gotOwner, wantOwner)
+	}
+	gotSources := got.DecodeSources("CUS")
+	if len(gotSources) != 2 || gotSources[0].Source != customPath || gotSources[1].Owner.EquipCatIDs != "U" {
+		t.Errorf("DecodeSources() got = %v", gotSources)
+	}
+	if gotSources := got.DecodeSources("ZZZ"); gotSources != nil {
+		t.Errorf("DecodeSources() got = %v, want nil", gotSources)
+	}
+
+	if _, err := NewOwnerDecoder(remotePath, []string{"missing.csv"}); err == nil {
+		t.Errorf("NewOwnerDecoder() error = nil, want err for owner sources without owners")
+	}
+}
+
+func TestNewILUOwnerDecoder(t *testing.T) {
+	dir := t.TempDir()
+	remotePath := filepath.Join(dir, "remote-ilu-owners.csv")
+	customPath := filepath.Join(dir, "custom-ilu-owners.csv")
+
+	want := &OwnerDecoder{
+		owners: map[string][]owner{
+			"CUSK": {{Source: customPath, Company: "my custom company", City: "my custom city", Country: "my custom country"}},
+		},
+	}
+
+	got, err := NewILUOwnerDecoder(remotePath, customPath)
+	if err != nil {
+		t.Errorf("NewILUOwnerDecoder() error = %v, want no err for empty ILU owner file", err)
+		return
+	}
+	if codes := got.GetAllOwnerCodes(); len(codes) != 0 {
+		t.Errorf("NewILUOwnerDecoder() owner keys = %v, want none of empty ILU owner file", codes)
+	}
+
+	_ = os.WriteFile(customPath, []byte("CUSK;my custom company;my custom city;my custom country"), 0o644)
+
+	got, err = NewILUOwnerDecoder(remotePath, customPath)
+	if err != nil {
+		t.Errorf("NewILUOwnerDecoder() error = %v, want no err", err)
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewILUOwnerDecoder() got = %v, want %v", got, want)
+	}
+
+	_ = os.WriteFile(customPath, []byte("CUS;my custom company;my custom city;my custom country"), 0o644)
+
+	if _, err := NewILUOwnerDecoder(remotePath, customPath); err == nil {
+		t.Errorf("NewILUOwnerDecoder() error = nil, want err for owner code without ILU letter")
+	}
+}
+
+func Test_readCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]owner
+		wantErr bool
+	}{
+		{
+			"Owner without registered equipment category IDs",
+			"ABC;company;city;country\n",
+			map[string]owner{"ABC": {Company: "company", City: "city", Country: "country"}},
+			false,
+		},
+		{
+			"Owner with registered equipment category IDs",
+			"ABC;company;city;country;UJ\n",
+			map[string]owner{"ABC": {Company: "company", City: "city", Country: "country", EquipCatIDs: "UJ"}},
+			false,
+		},
+		{
+			"Owner with invalid registered equipment category IDs",
+			"ABC;company;city;country;u\n",
+			nil,
+			true,
+		},
+		{
+			"Owner with too few fields",
+			"ABC;company;city\n",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCSV(strings.NewReader(tt.content), iso6346.IsOwnerCode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readCSV() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
@@ -0,0 +1,181 @@
+package file
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+const (
+	ownerSnapshotsDirName = "owner-snapshots"
+	ownerSnapshotPrefix   = "owner-"
+	ownerSnapshotExt      = ".csv"
+	// ownerSnapshotFormat is the time format of snapshot names, e.g. 20260102T150405Z.
+	ownerSnapshotFormat = "20060102T150405Z"
+)
+
+// ReadOwnersCSV reads owners of an owner CSV sorted by owner code.
+func ReadOwnersCSV(r io.Reader) ([]iso6346.Owner, error) {
+	ownersMap, err := readCSV(r, iso6346.IsOwnerCode)
+	if err != nil {
+		return nil, err
+	}
+	return ownersSortedByCode(ownersMap), nil
+}
+
+func ownersSortedByCode(ownersMap map[string]owner) []iso6346.Owner {
+	owners := make([]iso6346.Owner, 0, len(ownersMap))
+	for _, code := range slices.Sorted(maps.Keys(ownersMap)) {
+		o := ownersMap[code]
+		owners = append(owners, iso6346.Owner{
+			Code:        code,
+			Company:     o.Company,
+			City:        o.City,
+			Country:     o.Country,
+			EquipCatIDs: o.EquipCatIDs,
+		})
+	}
+	return owners
+}
+
+// OwnerSnapshots stores a snapshot of the owners of every download.
+type OwnerSnapshots struct {
+	dir string
+}
+
+// NewOwnerSnapshots creates the snapshot directory in path if it not exists and
+// returns a struct that uses this directory as a data source.
+func NewOwnerSnapshots(path string) (*OwnerSnapshots, error) {
+	dir := filepath.Join(path, ownerSnapshotsDirName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &OwnerSnapshots{dir: dir}, nil
+}
+
+// Save writes the owners as a snapshot named by the time and returns the name.
+// A snapshot never overwrites another snapshot. If a snapshot with the time
+// already exists the name has a suffix, e.g. 20260102T150405Z-2.
+func (s *OwnerSnapshots) Save(owners []iso6346.Owner, t time.Time) (string, error) {
+	timeName := t.UTC().Format(ownerSnapshotFormat)
+	name := timeName
+	var f *os.File
+	for n := 2; ; n++ {
+		var err error
+		f, err = os.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", err
+		}
+		name = fmt.Sprintf("%s-%d", timeName, n)
+	}
+	sorted := slices.SortedFunc(slices.Values(owners), func(a, b iso6346.Owner) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+	if err := WriteOwnersCSV(sorted, f); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return name, f.Close()
+}
+
+// Names returns the names of all snapshots from oldest to newest.
+func (s *OwnerSnapshots) Names() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name, found := strings.CutPrefix(entry.Name(), ownerSnapshotPrefix)
+		if !found || entry.IsDir() {
+			continue
+		}
+		name, found = strings.CutSuffix(name, ownerSnapshotExt)
+		if !found {
+			continue
+		}
+		if _, _, err := parseSnapshotName(name); err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		aTime, aNum, _ := parseSnapshotName(a)
+		bTime, bNum, _ := parseSnapshotName(b)
+		return cmp.Or(aTime.Compare(bTime), cmp.Compare(aNum, bNum))
+	})
+	return names, nil
+}
+
+// parseSnapshotName returns the time and the number of a snapshot name. The first
+// snapshot of a time has no suffix and number 1.
+func parseSnapshotName(name string) (time.Time, int, error) {
+	timeName, suffix, found := strings.Cut(name, "-")
+	t, err := time.Parse(ownerSnapshotFormat, timeName)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if !found {
+		return t, 1, nil
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 2 {
+		return time.Time{}, 0, fmt.Errorf("snapshot %s has invalid suffix", name)
+	}
+	return t, n, nil
+}
+
+// Read returns the owners of a snapshot sorted by owner code.
+func (s *OwnerSnapshots) Read(name string) ([]iso6346.Owner, error) {
+	if err := s.exists(name); err != nil {
+		return nil, err
+	}
+	ownersMap, err := readFile(s.path(name), iso6346.IsOwnerCode)
+	if err != nil {
+		return nil, err
+	}
+	return ownersSortedByCode(ownersMap), nil
+}
+
+// Prune removes the oldest snapshots so that keep snapshots remain.
+func (s *OwnerSnapshots) Prune(keep int) error {
+	names, err := s.Names()
+	if err != nil {
+		return err
+	}
+	for len(names) > max(keep, 0) {
+		if err := os.Remove(s.path(names[0])); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+func (s *OwnerSnapshots) exists(name string) error {
+	names, err := s.Names()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(names, name) {
+		return fmt.Errorf("snapshot %s does not exist in %s", name, s.dir)
+	}
+	return nil
+}
+
+func (s *OwnerSnapshots) path(name string) string {
+	return filepath.Join(s.dir, ownerSnapshotPrefix+name+ownerSnapshotExt)
+}
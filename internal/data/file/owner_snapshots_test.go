@@ -0,0 +1,110 @@
+package file
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func TestReadOwnersCSV(t *testing.T) {
+	got, err := ReadOwnersCSV(strings.NewReader("BBB;b company;b city;b country\nAAA;a company;a city;a country;UJ\n"))
+	if err != nil {
+		t.Fatalf("ReadOwnersCSV() error = %v", err)
+	}
+	want := []iso6346.Owner{
+		{Code: "AAA", Company: "a company", City: "a city", Country: "a country", EquipCatIDs: "UJ"},
+		{Code: "BBB", Company: "b company", City: "b city", Country: "b country"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ReadOwnersCSV() got = %v, want %v", got, want)
+	}
+}
+
+func TestOwnerSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	snapshots, err := NewOwnerSnapshots(dir)
+	if err != nil {
+		t.Fatalf("NewOwnerSnapshots() error = %v", err)
+	}
+
+	first := []iso6346.Owner{{Code: "BBB", Company: "b company"}, {Code: "AAA", Company: "a company"}}
+	second := []iso6346.Owner{{Code: "AAA", Company: "a new company"}}
+	third := []iso6346.Owner{{Code: "CCC", Company: "c company"}}
+
+	for i, owners := range [][]iso6346.Owner{first, second, third} {
+		if _, err := snapshots.Save(owners, time.Date(2026, time.Month(i+1), 1, 12, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	names, err := snapshots.Names()
+	if err != nil {
+		t.Fatalf("Names() error = %v", err)
+	}
+	wantNames := []string{"20260101T120000Z", "20260201T120000Z", "20260301T120000Z"}
+	if !slices.Equal(names, wantNames) {
+		t.Errorf("Names() got = %v, want %v", names, wantNames)
+	}
+
+	got, err := snapshots.Read("20260101T120000Z")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	wantOwners := []iso6346.Owner{{Code: "AAA", Company: "a company"}, {Code: "BBB", Company: "b company"}}
+	if !reflect.DeepEqual(got, wantOwners) {
+		t.Errorf("Read() got = %v, want %v", got, wantOwners)
+	}
+
+	if _, err := snapshots.Read("20250101T120000Z"); err == nil {
+		t.Errorf("Read() error = nil, want error for missing snapshot")
+	}
+
+	if err := snapshots.Prune(1); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	names, _ = snapshots.Names()
+	if want := []string{"20260301T120000Z"}; !slices.Equal(names, want) {
+		t.Errorf("Prune() got = %v, want %v", names, want)
+	}
+}
+
+func TestOwnerSnapshots_SaveSameTime(t *testing.T) {
+	snapshots, err := NewOwnerSnapshots(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewOwnerSnapshots() error = %v", err)
+	}
+
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var saved []string
+	for i := range 11 {
+		name, err := snapshots.Save([]iso6346.Owner{{Code: "AAA", Company: strconv.Itoa(i)}}, at)
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		saved = append(saved, name)
+	}
+	if saved[0] != "20260101T120000Z" || saved[1] != "20260101T120000Z-2" || saved[10] != "20260101T120000Z-11" {
+		t.Errorf("Save() got = %v, want names with suffixes -2 to -11", saved)
+	}
+
+	names, err := snapshots.Names()
+	if err != nil {
+		t.Fatalf("Names() error = %v", err)
+	}
+	if !slices.Equal(names, saved) {
+		t.Errorf("Names() got = %v, want %v", names, saved)
+	}
+
+	got, err := snapshots.Read("20260101T120000Z")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if want := []iso6346.Owner{{Code: "AAA", Company: "0"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() got = %v, want %v", got, want)
+	}
+}
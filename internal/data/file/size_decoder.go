@@ -6,7 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 const sizeFileName = "size.json"
@@ -41,12 +41,12 @@ func NewSizeDecoder(path string) (*LengthDecoder, *HeightWidthDecoder, error) {
 		return nil, nil, err
 	}
 	for lengthCode := range s.Length {
-		if err := cont.IsLengthCode(lengthCode); err != nil {
+		if err := iso6346.IsLengthCode(lengthCode); err != nil {
 			return nil, nil, err
 		}
 	}
 	for heightWidthCode := range s.HeightWidth {
-		if err := cont.IsHeightWidthCode(heightWidthCode); err != nil {
+		if err := iso6346.IsHeightWidthCode(heightWidthCode); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -59,9 +59,9 @@ type LengthDecoder struct {
 }
 
 // Decode returns length for a given length code.
-func (ld *LengthDecoder) Decode(code string) (bool, cont.Length) {
+func (ld *LengthDecoder) Decode(code string) (bool, iso6346.Length) {
 	if val, ok := ld.lengths[code]; ok {
-		return true, cont.Length(val)
+		return true, iso6346.Length(val)
 	}
 	return false, ""
 }
@@ -72,9 +72,9 @@ type HeightWidthDecoder struct {
 }
 
 // Decode returns height and width for given height and width code.
-func (hwd *HeightWidthDecoder) Decode(code string) (bool, cont.Height, cont.Width) {
+func (hwd *HeightWidthDecoder) Decode(code string) (bool, iso6346.Height, iso6346.Width) {
 	if val, ok := hwd.heightWidths[code]; ok {
-		return true, cont.Height(val.Height), cont.Width(val.Width)
+		return true, iso6346.Height(val.Height), iso6346.Width(val.Width)
 	}
 	return false, "", ""
 }
@@ -3,10 +3,11 @@ package file
 import (
 	_ "embed"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 const typeFileName = "type.json"
@@ -19,10 +20,23 @@ const groupFileName = "group.json"
 //go:embed group.json
 var groupJSON []byte
 
+const typeGroupFileName = "type-group.json"
+
+//go:embed type-group.json
+var typeGroupJSON []byte
+
+// typeGroup describes a type group code and the type codes of the group.
+type typeGroup struct {
+	Description string   `json:"description"`
+	Types       []string `json:"types"`
+}
+
 // TypeAndGroupDecoder holds types and groups for decoding.
 type TypeAndGroupDecoder struct {
-	types  map[string]string
-	groups map[string]string
+	types       map[string]string
+	groups      map[string]string
+	typeGroups  map[string]typeGroup
+	typeToGroup map[string]string
 }
 
 // NewTypeDecoder writes type and group file to path if it not exists and
@@ -54,24 +68,54 @@ func NewTypeDecoder(path string) (*TypeAndGroupDecoder, error) {
 		return nil, err
 	}
 	for typeCode := range typeAndGroup.types {
-		if err := cont.IsTypeCode(typeCode); err != nil {
+		if err := iso6346.IsTypeCode(typeCode); err != nil {
 			return nil, err
 		}
 	}
+
+	pathToTypeGroup := filepath.Join(path, typeGroupFileName)
+	if err := initFile(pathToTypeGroup, typeGroupJSON); err != nil {
+		return nil, err
+	}
+	b, err = os.ReadFile(pathToTypeGroup)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &typeAndGroup.typeGroups); err != nil {
+		return nil, err
+	}
+	typeAndGroup.typeToGroup = make(map[string]string)
+	for typeGroupCode, group := range typeAndGroup.typeGroups {
+		if err := iso6346.IsTypeGroupCode(typeGroupCode); err != nil {
+			return nil, err
+		}
+		if _, found := typeAndGroup.groups[typeGroupCode[:1]]; !found {
+			return nil, fmt.Errorf("%s: group of type group %s is not in %s", pathToTypeGroup, typeGroupCode, groupFileName)
+		}
+		for _, typeCode := range group.Types {
+			if _, found := typeAndGroup.types[typeCode]; !found {
+				return nil, fmt.Errorf("%s: type %s of type group %s is not in %s", pathToTypeGroup, typeCode, typeGroupCode, typeFileName)
+			}
+			if other, found := typeAndGroup.typeToGroup[typeCode]; found {
+				return nil, fmt.Errorf("%s: type %s is in type group %s and %s", pathToTypeGroup, typeCode, other, typeGroupCode)
+			}
+			typeAndGroup.typeToGroup[typeCode] = typeGroupCode
+		}
+	}
 	return typeAndGroup, nil
 }
 
 // Decode returns type and group information for the type code.
-func (tgd *TypeAndGroupDecoder) Decode(code string) (bool, cont.TypeInfo, cont.GroupInfo) {
+func (tgd *TypeAndGroupDecoder) Decode(code string) (bool, iso6346.TypeInfo, iso6346.GroupInfo) {
 	typeInfoStr, typeFound := tgd.types[code]
-	typeInfo := cont.TypeInfo(typeInfoStr)
+	typeInfo := iso6346.TypeInfo(typeInfoStr)
 
 	if !typeFound {
 		return false, "", ""
 	}
 
 	groupInfoStr, groupFound := tgd.groups[string(code[0])]
-	groupInfo := cont.GroupInfo(groupInfoStr)
+	groupInfo := iso6346.GroupInfo(groupInfoStr)
 
 	if !groupFound {
 		return false, "", ""
@@ -79,3 +123,18 @@ func (tgd *TypeAndGroupDecoder) Decode(code string) (bool, cont.TypeInfo, cont.G
 
 	return true, typeInfo, groupInfo
 }
+
+// DecodeTypeGroup returns type group and group information for the type group code.
+func (tgd *TypeAndGroupDecoder) DecodeTypeGroup(code string) (bool, iso6346.TypeGroupInfo, iso6346.GroupInfo) {
+	group, found := tgd.typeGroups[code]
+	if !found {
+		return false, "", ""
+	}
+	return true, iso6346.TypeGroupInfo(group.Description), iso6346.GroupInfo(tgd.groups[code[:1]])
+}
+
+// TypeGroupCode returns the type group code of the type code.
+func (tgd *TypeAndGroupDecoder) TypeGroupCode(typeCode string) (bool, string) {
+	typeGroupCode, found := tgd.typeToGroup[typeCode]
+	return found, typeGroupCode
+}
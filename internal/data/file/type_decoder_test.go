@@ -0,0 +1,63 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func TestTypeAndGroupDecoder_DecodeTypeGroup(t *testing.T) {
+	decoder, err := NewTypeDecoder(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewTypeDecoder() error = %v", err)
+	}
+	tests := []struct {
+		name          string
+		code          string
+		wantFound     bool
+		wantTypeGroup iso6346.TypeGroupInfo
+		wantGroup     iso6346.GroupInfo
+	}{
+		{"General purpose", "GP", true, "General purpose container without ventilation", "General purpose container"},
+		{"Refrigerated", "RE", true, "Thermal container, refrigerated", "Thermal container"},
+		{"Tank for non-dangerous liquids", "TN", true, "Tank container for non-dangerous liquids", "Tank container"},
+		{"Detailed type code", "G1", false, "", ""},
+		{"Unknown type group code", "XX", false, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, typeGroup, group := decoder.DecodeTypeGroup(tt.code)
+			if found != tt.wantFound || typeGroup != tt.wantTypeGroup || group != tt.wantGroup {
+				t.Errorf("DecodeTypeGroup() got = %v, %v, %v, want %v, %v, %v",
+					found, typeGroup, group, tt.wantFound, tt.wantTypeGroup, tt.wantGroup)
+			}
+		})
+	}
+}
+
+func TestTypeAndGroupDecoder_TypeGroupCode(t *testing.T) {
+	decoder, err := NewTypeDecoder(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewTypeDecoder() error = %v", err)
+	}
+	tests := []struct {
+		name      string
+		typeCode  string
+		wantFound bool
+		want      string
+	}{
+		{"General purpose", "G1", true, "GP"},
+		{"Ventilated", "V2", true, "VH"},
+		{"Refrigerated and heated", "R1", true, "RT"},
+		{"Open-top", "U1", true, "UT"},
+		{"Unknown type code", "G9", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, got := decoder.TypeGroupCode(tt.typeCode)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("TypeGroupCode() got = %v, %v, want %v, %v", found, got, tt.wantFound, tt.want)
+			}
+		})
+	}
+}
@@ -4,16 +4,16 @@ import (
 	"encoding/csv"
 	"io"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 // WriteOwnersCSV accepts a slice of owners and writes CSV to out.
-func WriteOwnersCSV(newOwners []cont.Owner, out io.Writer) error {
+func WriteOwnersCSV(newOwners []iso6346.Owner, out io.Writer) error {
 	csvWriter := csv.NewWriter(out)
 	csvWriter.Comma = csvSep
 
 	for _, o := range newOwners {
-		csvErr := csvWriter.Write([]string{o.Code, o.Company, o.City, o.Country})
+		csvErr := csvWriter.Write([]string{o.Code, o.Company, o.City, o.Country, o.EquipCatIDs})
 		if csvErr != nil {
 			return csvErr
 		}
@@ -4,20 +4,26 @@ import (
 	"bytes"
 	"testing"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 func TestWriteOwnersCSV(t *testing.T) {
 	tests := []struct {
 		name      string
-		newOwners []cont.Owner
+		newOwners []iso6346.Owner
 		wantOut   string
 		wantErr   bool
 	}{
 		{
 			"",
-			[]cont.Owner{{Code: "ABC", Company: "company", City: "city", Country: "country"}},
-			"ABC;company;city;country\n",
+			[]iso6346.Owner{{Code: "ABC", Company: "company", City: "city", Country: "country"}},
+			"ABC;company;city;country;\n",
+			false,
+		},
+		{
+			"Owner with registered equipment category IDs",
+			[]iso6346.Owner{{Code: "ABC", Company: "company", City: "city", Country: "country", EquipCatIDs: "UJ"}},
+			"ABC;company;city;country;UJ\n",
 			false,
 		},
 	}
@@ -2,40 +2,83 @@ package data
 
 import (
 	"io"
+	"time"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 // OwnerDecoder decodes a code to an owner or generates a random owner.
 type OwnerDecoder interface {
-	Decode(code string) (bool, cont.Owner)
+	Decode(code string) (bool, iso6346.Owner)
+
+	// DecodeSources returns the owners of a code of every owner source that has the code
+	// from lowest to highest priority. The last owner is the one returned by Decode.
+	DecodeSources(code string) []SourcedOwner
 
 	GetAllOwnerCodes() []string
 }
 
+// SourcedOwner is an owner with the name of its owner source, e.g. the path of an owner file.
+type SourcedOwner struct {
+	Source string
+	Owner  iso6346.Owner
+}
+
 // WriteOwnersCSVFunc represents a function that writes owners to an io.Writer.
-type WriteOwnersCSVFunc func(newOwners []cont.Owner, out io.Writer) error
+type WriteOwnersCSVFunc func(newOwners []iso6346.Owner, out io.Writer) error
+
+// ReadOwnersCSVFunc represents a function that reads owners of an io.Reader.
+type ReadOwnersCSVFunc func(r io.Reader) ([]iso6346.Owner, error)
+
+// OwnerSnapshots stores snapshots of downloaded owners named by the time of the download.
+type OwnerSnapshots interface {
+	Save(owners []iso6346.Owner, t time.Time) (string, error)
+
+	// Names returns the names of all snapshots from oldest to newest.
+	Names() ([]string, error)
+
+	Read(name string) ([]iso6346.Owner, error)
+
+	// Prune removes the oldest snapshots so that keep snapshots remain.
+	Prune(keep int) error
+}
 
 // EquipCatDecoder decodes an ID to an equipment category.
 type EquipCatDecoder interface {
-	Decode(ID string) (bool, cont.EquipCat)
+	Decode(ID string) (bool, iso6346.EquipCat)
 
 	AllCatIDs() []string
 }
 
 // LengthDecoder decodes a code to a length.
 type LengthDecoder interface {
-	Decode(code string) (bool, cont.Length)
+	Decode(code string) (bool, iso6346.Length)
 }
 
 // HeightWidthDecoder decodes a code to height and width.
 type HeightWidthDecoder interface {
-	Decode(code string) (bool, cont.Height, cont.Width)
+	Decode(code string) (bool, iso6346.Height, iso6346.Width)
 }
 
 // TypeDecoder decodes a code to type and group information.
 type TypeDecoder interface {
-	Decode(code string) (bool, cont.TypeInfo, cont.GroupInfo)
+	Decode(code string) (bool, iso6346.TypeInfo, iso6346.GroupInfo)
+
+	// DecodeTypeGroup decodes a type group code like GP to type group and group information.
+	DecodeTypeGroup(code string) (bool, iso6346.TypeGroupInfo, iso6346.GroupInfo)
+
+	// TypeGroupCode returns the type group code of a type code, e.g. GP for G1.
+	TypeGroupCode(typeCode string) (bool, string)
+}
+
+// LegacySizeTypeDecoder decodes a legacy size and type code to a current size and type code.
+type LegacySizeTypeDecoder interface {
+	Decode(code string) (bool, string)
+}
+
+// CountryDecoder decodes a legacy country code to a country.
+type CountryDecoder interface {
+	Decode(code string) (bool, iso6346.Country)
 }
 
 // TimestampUpdater updates a timestamp with an implemented time.
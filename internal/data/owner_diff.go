@@ -0,0 +1,59 @@
+package data
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+// OwnerChangeKind is the kind of change of an owner between two owner registries.
+type OwnerChangeKind string
+
+// Kinds of owner changes.
+const (
+	OwnerAdded   OwnerChangeKind = "added"
+	OwnerRemoved OwnerChangeKind = "removed"
+	OwnerChanged OwnerChangeKind = "changed"
+)
+
+// OwnerChange is a change of an owner. Previous is empty for an added owner
+// and Current is empty for a removed owner.
+type OwnerChange struct {
+	Kind     OwnerChangeKind
+	Code     string
+	Previous iso6346.Owner
+	Current  iso6346.Owner
+}
+
+// DiffOwners returns the changes from previous to current owners sorted by owner code.
+func DiffOwners(previous, current []iso6346.Owner) []OwnerChange {
+	previousMap := make(map[string]iso6346.Owner, len(previous))
+	for _, o := range previous {
+		previousMap[o.Code] = o
+	}
+	currentMap := make(map[string]iso6346.Owner, len(current))
+	for _, o := range current {
+		currentMap[o.Code] = o
+	}
+
+	var changes []OwnerChange
+	for code, p := range previousMap {
+		c, found := currentMap[code]
+		switch {
+		case !found:
+			changes = append(changes, OwnerChange{Kind: OwnerRemoved, Code: code, Previous: p})
+		case c != p:
+			changes = append(changes, OwnerChange{Kind: OwnerChanged, Code: code, Previous: p, Current: c})
+		}
+	}
+	for code, c := range currentMap {
+		if _, found := previousMap[code]; !found {
+			changes = append(changes, OwnerChange{Kind: OwnerAdded, Code: code, Current: c})
+		}
+	}
+	slices.SortFunc(changes, func(a, b OwnerChange) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return changes
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func TestDiffOwners(t *testing.T) {
+	aaa := iso6346.Owner{Code: "AAA", Company: "A Company", City: "A City", Country: "A Country"}
+	bbb := iso6346.Owner{Code: "BBB", Company: "B Company", City: "B City", Country: "B Country"}
+	bbbRenamed := iso6346.Owner{Code: "BBB", Company: "B New Company", City: "B City", Country: "B Country"}
+	ccc := iso6346.Owner{Code: "CCC", Company: "C Company", City: "C City", Country: "C Country"}
+
+	tests := []struct {
+		name     string
+		previous []iso6346.Owner
+		current  []iso6346.Owner
+		want     []OwnerChange
+	}{
+		{
+			"Same owners",
+			[]iso6346.Owner{aaa, bbb},
+			[]iso6346.Owner{bbb, aaa},
+			nil,
+		},
+		{
+			"Added, removed and changed owners",
+			[]iso6346.Owner{aaa, bbb},
+			[]iso6346.Owner{ccc, bbbRenamed},
+			[]OwnerChange{
+				{Kind: OwnerRemoved, Code: "AAA", Previous: aaa},
+				{Kind: OwnerChanged, Code: "BBB", Previous: bbb, Current: bbbRenamed},
+				{Kind: OwnerAdded, Code: "CCC", Current: ccc},
+			},
+		},
+		{
+			"Changed registered equipment category IDs",
+			[]iso6346.Owner{aaa},
+			[]iso6346.Owner{{Code: "AAA", Company: "A Company", City: "A City", Country: "A Country", EquipCatIDs: "UJ"}},
+			[]OwnerChange{
+				{
+					Kind:     OwnerChanged,
+					Code:     "AAA",
+					Previous: aaa,
+					Current:  iso6346.Owner{Code: "AAA", Company: "A Company", City: "A City", Country: "A Country", EquipCatIDs: "UJ"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffOwners(tt.previous, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffOwners() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package edifact reads equipment details of UN/EDIFACT interchanges.
+package edifact
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Delimiters are the service characters of an interchange.
+type Delimiters struct {
+	Component  byte
+	Element    byte
+	Decimal    byte
+	Release    byte
+	Repetition byte
+	Terminator byte
+}
+
+// DefaultDelimiters are used if an interchange has no UNA service string advice.
+var DefaultDelimiters = Delimiters{
+	Component:  ':',
+	Element:    '+',
+	Decimal:    '.',
+	Release:    '?',
+	Repetition: ' ',
+	Terminator: '\'',
+}
+
+// Segment is a segment with tag and data elements. Every data element has one or more components.
+type Segment struct {
+	Tag      string
+	Elements [][]string
+}
+
+// Value returns the component of the data element. Both start at 0.
+// An empty string is returned if the segment has no such component.
+func (s Segment) Value(element, component int) string {
+	if element >= len(s.Elements) || component >= len(s.Elements[element]) {
+		return ""
+	}
+	return s.Elements[element][component]
+}
+
+// Parse returns the segments of an interchange. The UNA service string advice
+// sets the delimiters and is not returned as segment.
+func Parse(data string) ([]Segment, error) {
+	delimiters := DefaultDelimiters
+	if strings.HasPrefix(data, "UNA") {
+		if len(data) < 9 {
+			return nil, errors.New("UNA service string advice is not 6 characters long")
+		}
+		delimiters = Delimiters{
+			Component:  data[3],
+			Element:    data[4],
+			Decimal:    data[5],
+			Release:    data[6],
+			Repetition: data[7],
+			Terminator: data[8],
+		}
+		data = data[9:]
+	}
+
+	var segments []Segment
+	for _, raw := range split(data, delimiters.Terminator, delimiters.Release) {
+		raw = strings.TrimLeft(raw, "\r\n")
+		if raw == "" {
+			continue
+		}
+		elements := split(raw, delimiters.Element, delimiters.Release)
+		segment := Segment{Tag: unescape(elements[0], delimiters.Release)}
+		if len(segment.Tag) != 3 {
+			return nil, fmt.Errorf("segment %s has no tag of 3 characters", raw)
+		}
+		for _, element := range elements[1:] {
+			var components []string
+			for _, component := range split(element, delimiters.Component, delimiters.Release) {
+				components = append(components, unescape(component, delimiters.Release))
+			}
+			segment.Elements = append(segment.Elements, components)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// split splits s at every sep that is not escaped by release.
+func split(s string, sep, release byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case release:
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unescape(s string, release byte) string {
+	if strings.IndexByte(s, release) == -1 {
+		return s
+	}
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] == release && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// QualifierContainer is the equipment type code qualifier of EQD segments with containers.
+const QualifierContainer = "CN"
+
+// Equipment is the equipment detail of an EQD segment.
+type Equipment struct {
+	// MessageRef is the message reference number of the UNH segment.
+	MessageRef string
+	// MessageType is the message type of the UNH segment, e.g. CODECO.
+	MessageType string
+	// SegmentNum is the position of the EQD segment in the message. The UNH segment is 1.
+	SegmentNum int
+	// Qualifier is the equipment type code qualifier, e.g. CN for container.
+	Qualifier string
+	// ID is the equipment identification, e.g. a container number.
+	ID string
+	// SizeType is the equipment size and type, e.g. 22G1.
+	SizeType string
+}
+
+// Equipments returns the equipment details of all EQD segments with qualifier
+// QualifierContainer in the messages of segments. EQD segments of other equipment
+// like chassis or trailers are skipped. Equipments returns an error if an EQD
+// segment is outside a message or has no equipment identification.
+func Equipments(segments []Segment) ([]Equipment, error) {
+	var equipments []Equipment
+	messageRef := ""
+	messageType := ""
+	segmentNum := 0
+	inMessage := false
+	for _, segment := range segments {
+		switch segment.Tag {
+		case "UNH":
+			messageRef = segment.Value(0, 0)
+			messageType = segment.Value(1, 0)
+			segmentNum = 0
+			inMessage = true
+		case "UNT":
+			inMessage = false
+		}
+		segmentNum++
+		if segment.Tag != "EQD" {
+			continue
+		}
+		if !inMessage {
+			return nil, errors.New("EQD segment is outside of a message")
+		}
+		qualifier := segment.Value(0, 0)
+		if qualifier != QualifierContainer {
+			continue
+		}
+		id := segment.Value(1, 0)
+		if id == "" {
+			return nil, fmt.Errorf("EQD segment %d of message %s has no equipment identification", segmentNum, messageRef)
+		}
+		equipments = append(equipments, Equipment{
+			MessageRef:  messageRef,
+			MessageType: messageType,
+			SegmentNum:  segmentNum,
+			Qualifier:   qualifier,
+			ID:          id,
+			SizeType:    segment.Value(2, 0),
+		})
+	}
+	return equipments, nil
+}
@@ -0,0 +1,119 @@
+package edifact
+
+import (
+	"reflect"
+	"testing"
+)
+
+const codeco = `UNA:+.? '
+UNB+UNOA:2+SENDER+RECEIVER+240101:1200+1'
+UNH+MSG1+CODECO:D:95B:UN:ITG14'
+BGM+34+DOC?+1+9'
+EQD+CN+ABCU6813040+22G1:102:5+++5'
+EQD+CN+ABCU1231231:6346:5'
+UNT+5+MSG1'
+UNH+MSG2+COPRAR:D:00B:UN:SMDG21'
+EQD+CH+CHASSIS1'
+EQD+CN+ABCU6813040:6346:5+45R1'
+UNT+4+MSG2'
+UNZ+2+1'
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Segment
+		wantErr bool
+	}{
+		{
+			"Default delimiters",
+			"UNH+1+CODECO:D'EQD+CN+ABCU6813040:6346'",
+			[]Segment{
+				{"UNH", [][]string{{"1"}, {"CODECO", "D"}}},
+				{"EQD", [][]string{{"CN"}, {"ABCU6813040", "6346"}}},
+			},
+			false,
+		},
+		{
+			"Custom delimiters and release character",
+			"UNA|*.! ~\nBGM*34*DOC!*1|A!~B~\n",
+			[]Segment{
+				{"BGM", [][]string{{"34"}, {"DOC*1", "A~B"}}},
+			},
+			false,
+		},
+		{
+			"Too short service string advice",
+			"UNA:+.",
+			nil,
+			true,
+		},
+		{
+			"Invalid tag",
+			"EQ+CN'",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEquipments(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Equipment
+		wantErr bool
+	}{
+		{
+			"Equipments of multiple messages",
+			codeco,
+			[]Equipment{
+				{"MSG1", "CODECO", 3, "CN", "ABCU6813040", "22G1"},
+				{"MSG1", "CODECO", 4, "CN", "ABCU1231231", ""},
+				{"MSG2", "COPRAR", 3, "CN", "ABCU6813040", "45R1"},
+			},
+			false,
+		},
+		{
+			"Equipment outside of message",
+			"UNB+UNOA:2+SENDER+RECEIVER'EQD+CN+ABCU6813040'",
+			nil,
+			true,
+		},
+		{
+			"Container equipment without identification",
+			"UNH+MSG1+CODECO:D:95B:UN:ITG14'EQD+CN++22G1'UNT+3+MSG1'",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := Parse(tt.data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, err := Equipments(segments)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Equipments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Equipments() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package edifact
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interchange is an interchange with messages that is written by Write.
+type Interchange struct {
+	Sender    string
+	Recipient string
+	Prepared  time.Time
+	// ControlRef is the interchange control reference of UNB and UNZ.
+	ControlRef string
+	Messages   []Message
+}
+
+// Message is a message of an interchange.
+type Message struct {
+	// Ref is the message reference number of UNH and UNT.
+	Ref string
+	// Identifier is the message identifier of UNH, e.g. CODECO, D, 95B, UN, ITG14.
+	Identifier []string
+	// Segments are the segments between UNH and UNT.
+	Segments []Segment
+}
+
+// Write writes the interchange with UNA service string advice and DefaultDelimiters.
+// The segment count of UNT and the message count of UNZ are calculated.
+// Every segment is written on a new line.
+func Write(w io.Writer, interchange Interchange) error {
+	d := DefaultDelimiters
+	segments := []Segment{{
+		Tag: "UNB",
+		Elements: [][]string{
+			{"UNOA", "2"},
+			{interchange.Sender},
+			{interchange.Recipient},
+			{interchange.Prepared.Format("060102"), interchange.Prepared.Format("1504")},
+			{interchange.ControlRef},
+		},
+	}}
+	for _, message := range interchange.Messages {
+		segments = append(segments, Segment{"UNH", [][]string{{message.Ref}, message.Identifier}})
+		segments = append(segments, message.Segments...)
+		segments = append(segments, Segment{"UNT", [][]string{
+			{strconv.Itoa(len(message.Segments) + 2)},
+			{message.Ref},
+		}})
+	}
+	segments = append(segments, Segment{"UNZ", [][]string{
+		{strconv.Itoa(len(interchange.Messages))},
+		{interchange.ControlRef},
+	}})
+
+	b := &strings.Builder{}
+	_, _ = fmt.Fprintf(b, "UNA%c%c%c%c%c%c\n", d.Component, d.Element, d.Decimal, d.Release, d.Repetition, d.Terminator)
+	for _, segment := range segments {
+		b.WriteString(segment.Tag)
+		for _, element := range segment.Elements {
+			b.WriteByte(d.Element)
+			for i, component := range element {
+				if i > 0 {
+					b.WriteByte(d.Component)
+				}
+				b.WriteString(escape(component, d))
+			}
+		}
+		b.WriteByte(d.Terminator)
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escape(s string, d Delimiters) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case d.Component, d.Element, d.Release, d.Terminator:
+			b.WriteByte(d.Release)
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
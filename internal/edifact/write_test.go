@@ -0,0 +1,56 @@
+package edifact
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	interchange := Interchange{
+		Sender:     "SENDER",
+		Recipient:  "RECEIVER",
+		Prepared:   time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC),
+		ControlRef: "42",
+		Messages: []Message{
+			{
+				Ref:        "1",
+				Identifier: []string{"CODECO", "D", "95B", "UN", "ITG14"},
+				Segments: []Segment{
+					{"BGM", [][]string{{"34"}, {"DOC+1"}, {"9"}}},
+					{"EQD", [][]string{{"CN"}, {"ABCU6813040"}, {"22G1", "102", "5"}, nil, nil, {"5"}}},
+				},
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	if err := Write(w, interchange); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	want := `UNA:+.? '
+UNB+UNOA:2+SENDER+RECEIVER+240102:1504+42'
+UNH+1+CODECO:D:95B:UN:ITG14'
+BGM+34+DOC?+1+9'
+EQD+CN+ABCU6813040+22G1:102:5+++5'
+UNT+4+1'
+UNZ+1+42'
+`
+	if got := w.String(); got != want {
+		t.Errorf("Write() = %v, want %v", got, want)
+	}
+
+	segments, err := Parse(w.String())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	equipments, err := Equipments(segments)
+	if err != nil {
+		t.Fatalf("Equipments() error = %v", err)
+	}
+	wantEquipments := []Equipment{{"1", "CODECO", 3, "CN", "ABCU6813040", "22G1"}}
+	if !reflect.DeepEqual(equipments, wantEquipments) {
+		t.Errorf("Equipments() = %v, want %v", equipments, wantEquipments)
+	}
+}
@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"golang.org/x/net/html"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 type OwnersDownloader struct {
@@ -21,7 +22,7 @@ func NewOwnersDownloader(ownerURL string) *OwnersDownloader {
 	return &OwnersDownloader{ownerURL: ownerURL}
 }
 
-func (od *OwnersDownloader) GetOwners(ctx context.Context) ([]cont.Owner, error) {
+func (od *OwnersDownloader) GetOwners(ctx context.Context) ([]iso6346.Owner, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", od.ownerURL, nil)
 	if err != nil {
 		return nil, err
@@ -45,13 +46,13 @@ func (od *OwnersDownloader) GetOwners(ctx context.Context) ([]cont.Owner, error)
 	return owners, nil
 }
 
-func parseOwners(body io.Reader) ([]cont.Owner, error) {
+func parseOwners(body io.Reader) ([]iso6346.Owner, error) {
 	doc, err := html.Parse(body)
 	if err != nil {
 		return nil, err
 	}
 
-	var owners []cont.Owner
+	var owners ownerList
 
 	for desc := range doc.Descendants() {
 
@@ -63,7 +64,7 @@ func parseOwners(body io.Reader) ([]cont.Owner, error) {
 				tr := tableRow(child1)
 				if tr != nil {
 					tdIdx := 0
-					var owner cont.Owner
+					var owner iso6346.Owner
 
 					for child2 := range tr.ChildNodes() {
 						td := tableData(child2)
@@ -86,7 +87,7 @@ func parseOwners(body io.Reader) ([]cont.Owner, error) {
 								if len(d) != 4 {
 									continue Rows
 								}
-								owner.Code = d[0:3]
+								owner.Code, owner.EquipCatIDs = splitBICCode(d)
 							case 1:
 								owner.Company = d
 							case 3:
@@ -97,16 +98,49 @@ func parseOwners(body io.Reader) ([]cont.Owner, error) {
 							tdIdx++
 						}
 					}
-					owners = append(owners, owner)
+					owners.add(owner)
 				}
 			}
 		}
 	}
 
-	if len(owners) == 0 {
+	if len(owners.owners) == 0 {
 		return nil, fmt.Errorf("parsing HTML failed because no owner was parsed")
 	}
-	return owners, nil
+	return owners.owners, nil
+}
+
+// ownerList is a list of owners in order of their first appearance.
+// The owner code is registered per equipment category ID, e.g. ABCU and ABCJ,
+// so every owner code is merged to one owner with its registered IDs.
+type ownerList struct {
+	owners []iso6346.Owner
+	idx    map[string]int
+}
+
+func (l *ownerList) add(owner iso6346.Owner) {
+	if l.idx == nil {
+		l.idx = make(map[string]int)
+	}
+	if i, found := l.idx[owner.Code]; found {
+		for _, ID := range owner.EquipCatIDs {
+			if !strings.ContainsRune(l.owners[i].EquipCatIDs, ID) {
+				l.owners[i].EquipCatIDs += string(ID)
+			}
+		}
+		return
+	}
+	l.idx[owner.Code] = len(l.owners)
+	l.owners = append(l.owners, owner)
+}
+
+// splitBICCode splits a BIC code like ABCU into owner code ABC and equipment category ID U.
+// Owner codes without equipment category ID are returned unchanged.
+func splitBICCode(code string) (string, string) {
+	if len(code) != 4 {
+		return code, ""
+	}
+	return code[0:3], code[3:4]
 }
 
 func tableData(node *html.Node) *html.Node {
@@ -6,31 +6,33 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 func Test_parseOwners(t *testing.T) {
 	tests := []struct {
 		name    string
 		body    io.Reader
-		want    []cont.Owner
+		want    []iso6346.Owner
 		wantErr bool
 	}{
 		{
 			"Parsing valid HTML body returns owners map",
 			validBody(),
-			[]cont.Owner{
+			[]iso6346.Owner{
 				{
-					Code:    "AAA",
-					Company: "A Company",
-					City:    "A City",
-					Country: "A Country",
+					Code:        "AAA",
+					Company:     "A Company",
+					City:        "A City",
+					Country:     "A Country",
+					EquipCatIDs: "UJ",
 				},
 				{
-					Code:    "BBB",
-					Company: "B Company",
-					City:    "B City",
-					Country: "B Country",
+					Code:        "BBB",
+					Company:     "B Company",
+					City:        "B City",
+					Country:     "B Country",
+					EquipCatIDs: "U",
 				},
 			},
 			false,
@@ -137,6 +139,17 @@ func validBody() io.Reader {
                                                 <a class="upperCase withArrow" href="/bic-codes/aaau/">View</a>
                                             </td>
                                         </tr>
+                                        <tr class="nostripe">
+                                            <td class="flexMobile align-items-center">AAAJ</span></td>
+                                            <td class="flexMobile align-items-center">A Company</span></td>
+                                            <td class="flexMobile align-items-center"></span></td>
+                                            <td class="flexMobile align-items-center">A City</span></td>
+                                            <td class="flexMobile align-items-center"></span></td>
+                                            <td class="flexMobile align-items-center">A Country</span></td>
+                                            <td class="no-sort flexMobile detailWidth align-items-center">
+                                                <a class="upperCase withArrow" href="/bic-codes/aaaj/">View</a>
+                                            </td>
+                                        </tr>
 <tr class="nostripe">
                                             <td class="flexMobile align-items-center">BBBU</span></td>
                                             <td class="flexMobile align-items-center">B Company</span></td>
@@ -0,0 +1,205 @@
+package http
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+// OwnerColumns maps the owner fields to column names of CSV files or keys of JSON objects.
+type OwnerColumns struct {
+	Code        string
+	Company     string
+	City        string
+	Country     string
+	EquipCatIDs string
+}
+
+// DefaultOwnerColumns are the column names of owner exports if no column is mapped.
+var DefaultOwnerColumns = OwnerColumns{
+	Code:        "code",
+	Company:     "company",
+	City:        "city",
+	Country:     "country",
+	EquipCatIDs: "equipment-category-ids",
+}
+
+// HTMLFileOwnersGetter reads owners from a saved page of ownerURL.
+type HTMLFileOwnersGetter struct {
+	path string
+}
+
+// NewHTMLFileOwnersGetter returns a new HTMLFileOwnersGetter.
+func NewHTMLFileOwnersGetter(path string) *HTMLFileOwnersGetter {
+	return &HTMLFileOwnersGetter{path: path}
+}
+
+func (g *HTMLFileOwnersGetter) GetOwners(_ context.Context) ([]iso6346.Owner, error) {
+	return readOwnersFile(g.path, parseOwners)
+}
+
+// CSVFileOwnersGetter reads owners from a CSV export with a header.
+type CSVFileOwnersGetter struct {
+	path      string
+	delimiter rune
+	columns   OwnerColumns
+}
+
+// NewCSVFileOwnersGetter returns a new CSVFileOwnersGetter.
+func NewCSVFileOwnersGetter(path string, delimiter rune, columns OwnerColumns) *CSVFileOwnersGetter {
+	return &CSVFileOwnersGetter{path: path, delimiter: delimiter, columns: columns}
+}
+
+func (g *CSVFileOwnersGetter) GetOwners(_ context.Context) ([]iso6346.Owner, error) {
+	return readOwnersFile(g.path, func(r io.Reader) ([]iso6346.Owner, error) {
+		return parseOwnersCSV(r, g.delimiter, g.columns)
+	})
+}
+
+// JSONFileOwnersGetter reads owners from a JSON export with an array of objects.
+type JSONFileOwnersGetter struct {
+	path    string
+	columns OwnerColumns
+}
+
+// NewJSONFileOwnersGetter returns a new JSONFileOwnersGetter.
+func NewJSONFileOwnersGetter(path string, columns OwnerColumns) *JSONFileOwnersGetter {
+	return &JSONFileOwnersGetter{path: path, columns: columns}
+}
+
+func (g *JSONFileOwnersGetter) GetOwners(_ context.Context) ([]iso6346.Owner, error) {
+	return readOwnersFile(g.path, func(r io.Reader) ([]iso6346.Owner, error) {
+		return parseOwnersJSON(r, g.columns)
+	})
+}
+
+func readOwnersFile(path string, parse func(io.Reader) ([]iso6346.Owner, error)) ([]iso6346.Owner, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	owners, err := parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return owners, nil
+}
+
+func parseOwnersCSV(r io.Reader, delimiter rune, columns OwnerColumns) ([]iso6346.Owner, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.Comma = delimiter
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("parsing CSV failed because header is missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{columns.Code, columns.Company} {
+		if !slices.Contains(header, column) {
+			return nil, fmt.Errorf("parsing CSV failed because column '%s' is missing", column)
+		}
+	}
+
+	var owners ownerList
+	line := 1
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line++
+
+		owner, err := newOwner(func(column string) string {
+			idx := slices.Index(header, column)
+			if idx == -1 || idx >= len(record) {
+				return ""
+			}
+			return record[idx]
+		}, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		owners.add(owner)
+	}
+
+	if len(owners.owners) == 0 {
+		return nil, fmt.Errorf("parsing CSV failed because no owner was parsed")
+	}
+	return owners.owners, nil
+}
+
+func parseOwnersJSON(r io.Reader, columns OwnerColumns) ([]iso6346.Owner, error) {
+	var objects []map[string]any
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, err
+	}
+
+	var owners ownerList
+	for i, object := range objects {
+		var valueErr error
+		owner, err := newOwner(func(key string) string {
+			switch value := object[key].(type) {
+			case nil:
+				return ""
+			case string:
+				return value
+			default:
+				valueErr = cmp.Or(valueErr, fmt.Errorf("value of '%s' is not a string", key))
+				return ""
+			}
+		}, columns)
+		if err = cmp.Or(valueErr, err); err != nil {
+			return nil, fmt.Errorf("object %d: %w", i, err)
+		}
+		owners.add(owner)
+	}
+
+	if len(owners.owners) == 0 {
+		return nil, fmt.Errorf("parsing JSON failed because no owner was parsed")
+	}
+	return owners.owners, nil
+}
+
+// newOwner returns an owner of the values of the columns. The owner code may be a
+// BIC code like ABCU that contains the registered equipment category ID.
+func newOwner(value func(column string) string, columns OwnerColumns) (iso6346.Owner, error) {
+	code := strings.ToUpper(strings.TrimSpace(value(columns.Code)))
+	if code == "" {
+		return iso6346.Owner{}, fmt.Errorf("value of '%s' is empty", columns.Code)
+	}
+	code, equipCatID := splitBICCode(code)
+
+	equipCatIDs := cmp.Or(strings.ToUpper(strings.TrimSpace(value(columns.EquipCatIDs))), equipCatID)
+	for _, ID := range equipCatIDs {
+		if err := iso6346.IsEquipCatID(string(ID)); err != nil {
+			return iso6346.Owner{}, err
+		}
+	}
+
+	country := strings.TrimSpace(value(columns.Country))
+	return iso6346.Owner{
+		Code:        code,
+		Company:     strings.TrimSpace(value(columns.Company)),
+		City:        strings.TrimSpace(value(columns.City)),
+		Country:     cmp.Or(countryCodeMap[country], country),
+		EquipCatIDs: equipCatIDs,
+	}, nil
+}
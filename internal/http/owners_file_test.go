@@ -0,0 +1,221 @@
+package http
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func Test_parseOwnersCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		delimiter rune
+		columns   OwnerColumns
+		want      []iso6346.Owner
+		wantErr   bool
+	}{
+		{
+			"Parse owners with default columns",
+			`code;company;city;country;equipment-category-ids
+AAA;A Company;A City;DE;UJ
+BBB;B Company;B City;B Country;
+`,
+			';',
+			DefaultOwnerColumns,
+			[]iso6346.Owner{
+				{Code: "AAA", Company: "A Company", City: "A City", Country: "Germany", EquipCatIDs: "UJ"},
+				{Code: "BBB", Company: "B Company", City: "B City", Country: "B Country"},
+			},
+			false,
+		},
+		{
+			"Parse owners with mapped columns and merge BIC codes",
+			`BIC,Name,Town
+aaau,A Company,A City
+AAAJ,A Company,A City
+`,
+			',',
+			OwnerColumns{Code: "BIC", Company: "Name", City: "Town"},
+			[]iso6346.Owner{
+				{Code: "AAA", Company: "A Company", City: "A City", EquipCatIDs: "UJ"},
+			},
+			false,
+		},
+		{
+			"Parse owners with short record",
+			`code,company,city
+AAA,A Company
+`,
+			',',
+			DefaultOwnerColumns,
+			[]iso6346.Owner{
+				{Code: "AAA", Company: "A Company"},
+			},
+			false,
+		},
+		{
+			"Missing mapped column returns error",
+			`code,name
+AAA,A Company
+`,
+			',',
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"Empty owner code returns error",
+			`code,company
+,A Company
+`,
+			',',
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"Invalid equipment category ID returns error",
+			`code,company,equipment-category-ids
+AAA,A Company,1
+`,
+			',',
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"No owner returns error",
+			`code,company
+`,
+			',',
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"Empty file returns error",
+			``,
+			',',
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOwnersCSV(strings.NewReader(tt.body), tt.delimiter, tt.columns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOwnersCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseOwnersCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseOwnersJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		columns OwnerColumns
+		want    []iso6346.Owner
+		wantErr bool
+	}{
+		{
+			"Parse owners with default keys",
+			`[
+  {"code": "AAA", "company": "A Company", "city": "A City", "country": "DE", "equipment-category-ids": "U"},
+  {"code": "BBB", "company": "B Company"}
+]`,
+			DefaultOwnerColumns,
+			[]iso6346.Owner{
+				{Code: "AAA", Company: "A Company", City: "A City", Country: "Germany", EquipCatIDs: "U"},
+				{Code: "BBB", Company: "B Company"},
+			},
+			false,
+		},
+		{
+			"Parse owners with mapped keys and merge BIC codes",
+			`[{"bic": "AAAU", "name": "A Company"}, {"bic": "AAAZ", "name": "A Company"}]`,
+			OwnerColumns{Code: "bic", Company: "name"},
+			[]iso6346.Owner{
+				{Code: "AAA", Company: "A Company", EquipCatIDs: "UZ"},
+			},
+			false,
+		},
+		{
+			"Value that is not a string returns error",
+			`[{"code": "AAA", "company": 1}]`,
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"Missing owner code returns error",
+			`[{"company": "A Company"}]`,
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"Object instead of array returns error",
+			`{"code": "AAA"}`,
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+		{
+			"No owner returns error",
+			`[]`,
+			DefaultOwnerColumns,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOwnersJSON(strings.NewReader(tt.body), tt.columns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOwnersJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseOwnersJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLFileOwnersGetter_GetOwners(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "page.html")
+	b, err := io.ReadAll(validBody())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NewHTMLFileOwnersGetter(path).GetOwners(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := parseOwners(validBody())
+	if !slices.Equal(got, want) {
+		t.Errorf("GetOwners() = %v, want %v", got, want)
+	}
+
+	_, err = NewHTMLFileOwnersGetter(filepath.Join(t.TempDir(), "missing.html")).GetOwners(context.Background())
+	if err == nil {
+		t.Errorf("GetOwners() of missing file error = nil, want error")
+	}
+}
@@ -3,10 +3,10 @@ package http
 import (
 	"context"
 
-	"github.com/mrclmr/icm/internal/cont"
+	"github.com/mrclmr/icm/iso6346"
 )
 
 // OwnersGetter downloads owners.
 type OwnersGetter interface {
-	GetOwners(context.Context) ([]cont.Owner, error)
+	GetOwners(context.Context) ([]iso6346.Owner, error)
 }
@@ -2,28 +2,18 @@ package input
 
 import (
 	"encoding/csv"
+	"errors"
+	"strings"
+
+	"github.com/mrclmr/icm/iso6346"
 )
 
-// Datum represents a datum that is be used by CSVPrinter.
-type Datum struct {
-	header string
-	value  string
-}
-
-// NewDatum returns a new Datum.
-func NewDatum(header string) Datum {
-	return Datum{header: header}
-}
-
-// WithValue sets value and returns Datum.
-func (d Datum) WithValue(value string) Datum {
-	d.value = value
-	return d
-}
+const errorCodeHeader = "error-code"
 
 // CSVPrinter prints the set record. Use SetRecord to set a record.
 type CSVPrinter struct {
 	csvWriter     *csv.Writer
+	fixedHeaders  []string
 	headers       []string
 	record        []string
 	headerPrinted bool
@@ -38,18 +28,45 @@ func NewCSVPrinter(csvWriter *csv.Writer, noHeader bool) *CSVPrinter {
 	}
 }
 
+// SetHeaders sets fixed headers for the data of inputs. Inputs of different
+// patterns are printed in the same columns and missing data are left empty.
+func (cp *CSVPrinter) SetHeaders(headers []string) *CSVPrinter {
+	cp.fixedHeaders = headers
+	return cp
+}
+
 // Print writes set record to passed writer.
+// The first columns are data, the last column has the error codes of invalid inputs.
 // No header is printed if noHeader is set to false.
 // Print returns an error if writing to writer fails.
-func (cp *CSVPrinter) Print(inputs []Input) error {
+func (cp *CSVPrinter) Print(inputs []Input, data ...Datum) error {
 	cp.headers = nil
 	cp.record = nil
-	for _, input := range inputs {
-		for _, datum := range input.data {
-			cp.headers = append(cp.headers, datum.header)
-			cp.record = append(cp.record, datum.value)
+	for _, datum := range data {
+		cp.headers = append(cp.headers, datum.header)
+		cp.record = append(cp.record, datum.value)
+	}
+	if cp.fixedHeaders == nil {
+		for _, input := range inputs {
+			for _, datum := range input.data {
+				cp.headers = append(cp.headers, datum.header)
+				cp.record = append(cp.record, datum.value)
+			}
+		}
+	} else {
+		values := map[string]string{}
+		for _, input := range inputs {
+			for _, datum := range input.data {
+				values[datum.header] = datum.value
+			}
+		}
+		for _, header := range cp.fixedHeaders {
+			cp.headers = append(cp.headers, header)
+			cp.record = append(cp.record, values[header])
 		}
 	}
+	cp.headers = append(cp.headers, errorCodeHeader)
+	cp.record = append(cp.record, strings.Join(errorCodes(inputs), ", "))
 
 	if !cp.noHeader && !cp.headerPrinted {
 		err := cp.csvWriter.Write(cp.headers)
@@ -65,3 +82,19 @@ func (cp *CSVPrinter) Print(inputs []Input) error {
 	cp.csvWriter.Flush()
 	return nil
 }
+
+func errorCodes(inputs []Input) []string {
+	var codes []string
+	for _, input := range inputs {
+		if input.err == nil {
+			continue
+		}
+		var validateErr *iso6346.ValidateError
+		if errors.As(input.err, &validateErr) {
+			codes = append(codes, string(validateErr.Kind))
+			continue
+		}
+		codes = append(codes, string(iso6346.ErrorKindUnknown))
+	}
+	return codes
+}
@@ -3,14 +3,19 @@ package input
 import (
 	"bytes"
 	"encoding/csv"
+	"errors"
 	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
 )
 
 func TestCSVPrinter_Print(t *testing.T) {
 	tests := []struct {
 		name       string
 		noHeader   bool
+		headers    []string
 		inputs     []Input
+		data       []Datum
 		wantWriter string
 	}{
 		{
@@ -25,8 +30,8 @@ func TestCSVPrinter_Print(t *testing.T) {
 					},
 				},
 			},
-			wantWriter: `header-1,header-2,header-3
-value-1,value-2,value-3
+			wantWriter: `header-1,header-2,header-3,error-code
+value-1,value-2,value-3,
 `,
 		},
 		{
@@ -40,7 +45,41 @@ value-1,value-2,value-3
 					},
 				},
 			},
-			wantWriter: `value-1,value-2
+			wantWriter: `value-1,value-2,
+`,
+		},
+		{
+			name:     "Print CSV with error codes",
+			noHeader: false,
+			inputs: []Input{
+				{
+					data: []Datum{{header: "header-1", value: "value-1"}},
+					err:  iso6346.NewFieldError(iso6346.ErrorKindOwnerNotRegistered, iso6346.FieldOwnerCode, "", ""),
+				},
+				{
+					data: []Datum{{header: "header-2", value: "value-2"}},
+				},
+				{
+					data: []Datum{{header: "header-3", value: "value-3"}},
+					err:  errors.New("some error"),
+				},
+			},
+			wantWriter: `header-1,header-2,header-3,error-code
+value-1,value-2,value-3,"owner-not-registered, unknown"
+`,
+		},
+		{
+			name:     "Print CSV with data and fixed headers",
+			noHeader: false,
+			headers:  []string{"header-1", "header-2", "header-3"},
+			inputs: []Input{
+				{
+					data: []Datum{{header: "header-3", value: "value-3"}},
+				},
+			},
+			data: []Datum{{header: "pattern", value: "some-pattern"}},
+			wantWriter: `pattern,header-1,header-2,header-3,error-code
+some-pattern,,,value-3,
 `,
 		},
 	}
@@ -49,8 +88,8 @@ value-1,value-2,value-3
 			writer := &bytes.Buffer{}
 
 			csvWriter := csv.NewWriter(writer)
-			csvPrinter := NewCSVPrinter(csvWriter, tt.noHeader)
-			_ = csvPrinter.Print(tt.inputs)
+			csvPrinter := NewCSVPrinter(csvWriter, tt.noHeader).SetHeaders(tt.headers)
+			_ = csvPrinter.Print(tt.inputs, tt.data...)
 
 			csvWriter.Flush()
 
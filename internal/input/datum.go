@@ -0,0 +1,72 @@
+package input
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Datum represents a datum that is used by CSVPrinter and JSONPrinter.
+type Datum struct {
+	header     string
+	value      string
+	typedValue any
+	object     string
+	key        string
+}
+
+// NewDatum returns a new Datum.
+func NewDatum(header string) Datum {
+	return Datum{header: header}
+}
+
+// WithValue sets value and returns Datum.
+func (d Datum) WithValue(value string) Datum {
+	d.value = value
+	return d
+}
+
+// WithBoolValue sets a boolean value and returns Datum.
+func (d Datum) WithBoolValue(value bool) Datum {
+	d.value = strconv.FormatBool(value)
+	d.typedValue = value
+	return d
+}
+
+// WithIntValue sets a number value and returns Datum.
+func (d Datum) WithIntValue(value int) Datum {
+	d.value = strconv.Itoa(value)
+	d.typedValue = value
+	return d
+}
+
+// WithListValue sets a list value and returns Datum.
+// CSVPrinter joins the values with ", ".
+func (d Datum) WithListValue(values []string) Datum {
+	d.value = strings.Join(values, ", ")
+	d.typedValue = append([]string{}, values...)
+	return d
+}
+
+// WithTypedValue sets value for CSVPrinter and typedValue for JSONPrinter and returns Datum.
+func (d Datum) WithTypedValue(value string, typedValue any) Datum {
+	d.value = value
+	d.typedValue = typedValue
+	return d
+}
+
+// InObject nests the datum as key in object for JSONPrinter and returns Datum.
+func (d Datum) InObject(object string, key string) Datum {
+	d.object = object
+	d.key = key
+	return d
+}
+
+func (d Datum) jsonValue() any {
+	if d.typedValue != nil {
+		return d.typedValue
+	}
+	if d.value == "" {
+		return nil
+	}
+	return d.value
+}
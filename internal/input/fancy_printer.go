@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -24,6 +25,7 @@ type FancyPrinter struct {
 	indent         string
 	separators     []string
 	separatorsFunc func(inputs []Input)
+	notes          []string
 }
 
 // NewFancyPrinter creates a FancyPrinter.
@@ -49,8 +51,15 @@ func (fp *FancyPrinter) SetSeparatorsFunc(separatorsFunc func(inputs []Input)) {
 	fp.separatorsFunc = separatorsFunc
 }
 
-// Print writes formatted inputs to writer.
-func (fp *FancyPrinter) Print(inputs []Input) error {
+// SetNotes sets the headers of data that are printed as notes below the inputs.
+func (fp *FancyPrinter) SetNotes(headers ...string) *FancyPrinter {
+	fp.notes = headers
+	return fp
+}
+
+// Print writes formatted inputs to writer. Only data with a header set by SetNotes
+// and a non-empty value are printed.
+func (fp *FancyPrinter) Print(inputs []Input, data ...Datum) error {
 	if fp.separatorsFunc != nil {
 		fp.separatorsFunc(inputs)
 	}
@@ -99,6 +108,12 @@ func (fp *FancyPrinter) Print(inputs []Input) error {
 	if err != nil {
 		return err
 	}
+	for _, datum := range data {
+		if datum.value == "" || !slices.Contains(fp.notes, datum.header) {
+			continue
+		}
+		_, _ = fmt.Fprintf(b, "%s%s: %s\n", fp.indent, datum.header, datum.value)
+	}
 	_, _ = fmt.Fprintln(b)
 	_, _ = io.WriteString(fp.writer, b.String())
 
@@ -345,3 +345,25 @@ a---b‧‧‧c  ✔
 		})
 	}
 }
+
+func TestFancyPrinter_PrintNotes(t *testing.T) {
+	writer := &bytes.Buffer{}
+	fp := NewFancyPrinter(writer).SetIndent("  ").SetNotes("note", "empty-note")
+	err := fp.Print(
+		[]Input{{value: "a"}},
+		NewDatum("note").WithValue("text"),
+		NewDatum("empty-note"),
+		NewDatum("other").WithValue("other text"),
+	)
+	if err != nil {
+		t.Errorf("FancyPrinter.Print() error = %v", err)
+	}
+	want := `
+  a  ✔
+  note: text
+
+`
+	if gotWriter := writer.String(); gotWriter != want {
+		t.Errorf("FancyPrinter.Print() = %v, want %v", gotWriter, want)
+	}
+}
@@ -0,0 +1,121 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+// JSONPrinter prints one JSON object per inputs.
+type JSONPrinter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONPrinter creates a new JSONPrinter.
+func NewJSONPrinter(encoder *json.Encoder) *JSONPrinter {
+	return &JSONPrinter{
+		encoder: encoder,
+	}
+}
+
+type jsonError struct {
+	Kind     iso6346.ErrorKind `json:"kind"`
+	Field    iso6346.Field     `json:"field,omitempty"`
+	Offset   int               `json:"offset"`
+	Expected string            `json:"expected,omitempty"`
+}
+
+// Print writes data and inputs as JSON object to encoder.
+// Values keep their type and the order of the data. The object ends with
+// the fields "valid" and "errors".
+// Print returns an error if writing to encoder fails.
+func (jp *JSONPrinter) Print(inputs []Input, data ...Datum) error {
+	obj := &object{}
+	for _, datum := range data {
+		obj.set(datum.header, datum.jsonValue())
+	}
+	for _, input := range inputs {
+		for _, datum := range input.data {
+			if datum.object == "" {
+				obj.set(datum.header, datum.jsonValue())
+				continue
+			}
+			nested, ok := obj.get(datum.object).(*object)
+			if !ok {
+				nested = &object{}
+				obj.set(datum.object, nested)
+			}
+			nested.set(datum.key, datum.jsonValue())
+		}
+	}
+	errs := jsonErrors(inputs)
+	obj.set("valid", len(errs) == 0)
+	obj.set("errors", errs)
+	return jp.encoder.Encode(obj)
+}
+
+func jsonErrors(inputs []Input) []jsonError {
+	errs := []jsonError{}
+	for _, input := range inputs {
+		if input.err == nil {
+			continue
+		}
+		var validateErr *iso6346.ValidateError
+		if errors.As(input.err, &validateErr) {
+			errs = append(errs, jsonError{
+				Kind:     validateErr.Kind,
+				Field:    validateErr.Field,
+				Offset:   validateErr.Offset,
+				Expected: validateErr.Expected,
+			})
+			continue
+		}
+		errs = append(errs, jsonError{Kind: iso6346.ErrorKindUnknown})
+	}
+	return errs
+}
+
+// object is a JSON object that keeps the insertion order of its keys.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *object) set(key string, value any) {
+	if o.values == nil {
+		o.values = map[string]any{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *object) get(key string) any {
+	return o.values[key]
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	b := &bytes.Buffer{}
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func TestJSONPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputs     []Input
+		data       []Datum
+		wantWriter string
+	}{
+		{
+			name: "Print typed values",
+			inputs: []Input{
+				{
+					data: []Datum{
+						NewDatum("header-1").WithValue("value-1"),
+						NewDatum("header-2"),
+						NewDatum("header-3").WithBoolValue(true),
+						NewDatum("header-4").WithIntValue(7),
+						NewDatum("header-5").WithListValue([]string{"value-5", "value-6"}),
+						NewDatum("header-6").WithListValue(nil),
+					},
+				},
+			},
+			wantWriter: `{"header-1":"value-1","header-2":null,"header-3":true,"header-4":7,` +
+				`"header-5":["value-5","value-6"],"header-6":[],"valid":true,"errors":[]}
+`,
+		},
+		{
+			name: "Print nested object",
+			inputs: []Input{
+				{
+					data: []Datum{
+						NewDatum("header-1").InObject("object", "key-1").WithValue("value-1"),
+						NewDatum("header-2").WithValue("value-2"),
+					},
+				},
+				{
+					data: []Datum{
+						NewDatum("header-3").InObject("object", "key-2").WithValue("value-3"),
+					},
+				},
+			},
+			wantWriter: `{"object":{"key-1":"value-1","key-2":"value-3"},"header-2":"value-2","valid":true,"errors":[]}
+`,
+		},
+		{
+			name: "Print errors",
+			inputs: []Input{
+				{
+					data: []Datum{NewDatum("header-1").WithValue("value-1")},
+					err: iso6346.NewFieldError(iso6346.ErrorKindCheckDigitMismatch, iso6346.FieldCheckDigit, "7",
+						"some message"),
+				},
+				{
+					err: errors.New("some error"),
+				},
+			},
+			wantWriter: `{"header-1":"value-1","valid":false,"errors":[` +
+				`{"kind":"check-digit-mismatch","field":"check-digit","offset":10,"expected":"7"},` +
+				`{"kind":"unknown","offset":0}]}
+`,
+		},
+		{
+			name: "Print data before inputs",
+			inputs: []Input{
+				{
+					data: []Datum{NewDatum("header-1").WithValue("value-1")},
+				},
+			},
+			data: []Datum{NewDatum("pattern").WithValue("some-pattern")},
+			wantWriter: `{"pattern":"some-pattern","header-1":"value-1","valid":true,"errors":[]}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+
+			jsonPrinter := NewJSONPrinter(json.NewEncoder(writer))
+			if err := jsonPrinter.Print(tt.inputs, tt.data...); err != nil {
+				t.Errorf("Print() error = %v", err)
+			}
+
+			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
+				t.Errorf("gotWriter = %v, want %v", gotWriter, tt.wantWriter)
+			}
+		})
+	}
+}
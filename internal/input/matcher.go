@@ -1,12 +1,23 @@
 package input
 
+// Pattern is a named sequence of inputs.
+type Pattern struct {
+	Name      string
+	NewInputs []func() Input
+}
+
+// NewPattern returns a new Pattern.
+func NewPattern(name string, newInputs ...func() Input) Pattern {
+	return Pattern{Name: name, NewInputs: newInputs}
+}
+
 // Match returns pattern if all values are valid formatted. If no pattern
 // meets the requirement the first pattern is returned.
-func Match(in string, patterns [][]func() Input) []func() Input {
-	for _, newInputs := range patterns {
+func Match(in string, patterns []Pattern) Pattern {
+	for _, pattern := range patterns {
 		inTemp := in
 		allValidFmt := true
-		for _, newInput := range newInputs {
+		for _, newInput := range pattern.NewInputs {
 			input := newInput()
 			matchIndex := input.matchIndex(inTemp)
 			if matchIndex != nil {
@@ -16,8 +27,47 @@ func Match(in string, patterns [][]func() Input) []func() Input {
 			allValidFmt = allValidFmt && input.isValidFmt()
 		}
 		if allValidFmt {
-			return newInputs
+			return pattern
 		}
 	}
 	return patterns[0]
 }
+
+// Headers returns the headers of the data of all patterns in order of
+// first appearance. Every header is returned once.
+func Headers(patterns []Pattern) []string {
+	return uniqueKeys(patterns, func(datum Datum) string {
+		return datum.header
+	})
+}
+
+// Keys returns the keys of the data of all patterns that JSONPrinter prints
+// at the top level of an object. Data in an object have the key of the object.
+// Every key is returned once.
+func Keys(patterns []Pattern) []string {
+	return uniqueKeys(patterns, func(datum Datum) string {
+		if datum.object != "" {
+			return datum.object
+		}
+		return datum.header
+	})
+}
+
+func uniqueKeys(patterns []Pattern, key func(datum Datum) string) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		inputs, _ := Validate("", pattern.NewInputs)
+		for _, input := range inputs {
+			for _, datum := range input.data {
+				k := key(datum)
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
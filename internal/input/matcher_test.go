@@ -1,6 +1,7 @@
 package input
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -31,44 +32,90 @@ func TestMatcher_Match(t *testing.T) {
 
 	tests := []struct {
 		name          string
-		inputPatterns [][]func() Input
+		inputPatterns []Pattern
 		in            string
-		wantedLen     int
+		wantedName    string
 	}{
 		{
 			"Use first pattern",
-			[][]func() Input{
-				{match1},
-				{match1, match2},
+			[]Pattern{
+				NewPattern("pattern-1", match1),
+				NewPattern("pattern-2", match1, match2),
 			},
 			"a",
-			1,
+			"pattern-1",
 		},
 		{
 			"Use first pattern as default",
-			[][]func() Input{
-				{noMatch},
-				{match2, noMatch},
+			[]Pattern{
+				NewPattern("pattern-1", noMatch),
+				NewPattern("pattern-2", match2, noMatch),
 			},
 			"abcd",
-			1,
+			"pattern-1",
 		},
 		{
 			"Use first best match",
-			[][]func() Input{
-				{noMatch},
-				{match1, noMatch},
-				{match1, match1, match1},
+			[]Pattern{
+				NewPattern("pattern-1", noMatch),
+				NewPattern("pattern-2", match1, noMatch),
+				NewPattern("pattern-3", match1, match1, match1),
 			},
 			"abcd",
-			3,
+			"pattern-3",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if patternIdx := Match(tt.in, tt.inputPatterns); len(patternIdx) != tt.wantedLen {
-				t.Errorf("Match() = %v, want length %v", patternIdx, tt.wantedLen)
+			if pattern := Match(tt.in, tt.inputPatterns); pattern.Name != tt.wantedName {
+				t.Errorf("Match() = %v, want %v", pattern.Name, tt.wantedName)
 			}
 		})
 	}
 }
+
+func TestHeaders(t *testing.T) {
+	newInput := func(headers ...string) func() Input {
+		return func() Input {
+			return NewInput(1, func(_ string) []int { return nil },
+				func(_ string, _ []string) ([]string, []Datum, error) {
+					var data []Datum
+					for _, header := range headers {
+						data = append(data, NewDatum(header))
+					}
+					return nil, data, nil
+				})
+		}
+	}
+	patterns := []Pattern{
+		NewPattern("pattern-1", newInput("header-1", "header-2"), newInput("header-3")),
+		NewPattern("pattern-2", newInput("header-1"), newInput("header-4")),
+	}
+
+	got := Headers(patterns)
+	want := []string{"header-1", "header-2", "header-3", "header-4"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Headers() = %v, want %v", got, want)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	newInput := func(data ...Datum) func() Input {
+		return func() Input {
+			return NewInput(1, func(_ string) []int { return nil },
+				func(_ string, _ []string) ([]string, []Datum, error) {
+					return nil, data, nil
+				})
+		}
+	}
+	patterns := []Pattern{
+		NewPattern("pattern-1", newInput(NewDatum("header-1"), NewDatum("header-2").InObject("object", "key-2"))),
+		NewPattern("pattern-2", newInput(NewDatum("header-3").InObject("object", "key-3"), NewDatum("header-4"))),
+	}
+
+	got := Keys(patterns)
+	want := []string{"header-1", "object", "header-4"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package input
 
 // Printer prints inputs and returns nil if no error occurred.
+// The data are printed before the data of inputs, e.g. the matched pattern.
 type Printer interface {
-	Print(inputs []Input) error
+	Print(inputs []Input, data ...Datum) error
 }
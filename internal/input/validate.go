@@ -1,15 +1,21 @@
 package input
 
 import (
+	"errors"
 	"strings"
 	"unicode/utf8"
+
+	"github.com/mrclmr/icm/iso6346"
 )
 
 // Validate validates inputs. Each input is validated and values are assigned.
+// The offset of an iso6346.ValidateError of an input is set to the offset of the
+// input in the matched values, e.g. 13 for the length code of ABCU1234560DE22G1.
 func Validate(in string, newInputs []func() Input) ([]Input, error) {
 	var previousValues []string
 	var inputs []Input
 	var err error
+	offset := 0
 	for _, newInput := range newInputs {
 		input := newInput()
 		input.previousValues = previousValues
@@ -27,6 +33,12 @@ func Validate(in string, newInputs []func() Input) ([]Input, error) {
 		previousValues = append([]string{input.value}, previousValues...)
 		input.validateValue()
 
+		var validateErr *iso6346.ValidateError
+		if errors.As(input.err, &validateErr) {
+			validateErr.Offset = offset
+		}
+		offset += utf8.RuneCountInString(input.value)
+
 		inputs = append(inputs, input)
 
 		if err == nil {
@@ -2,7 +2,10 @@ package input
 
 import (
 	"errors"
+	"regexp"
 	"testing"
+
+	"github.com/mrclmr/icm/iso6346"
 )
 
 func TestInputHasCorrectValue(t *testing.T) {
@@ -144,3 +147,29 @@ func TestInputHasCorrectValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSetsOffsetOfValidateError(t *testing.T) {
+	newInput := func(pattern string, err error) func() Input {
+		return func() Input {
+			return NewInput(len(pattern), regexp.MustCompile(pattern).FindStringIndex,
+				func(_ string, _ []string) ([]string, []Datum, error) {
+					return nil, nil, err
+				})
+		}
+	}
+	lengthErr := iso6346.NewFieldError(iso6346.ErrorKindUnknownLengthCode, iso6346.FieldLengthCode, "", "")
+	inputs, err := Validate("ABC U 123456 0 DE 92G1", []func() Input{
+		newInput(`[A-Z]{3}`, nil),
+		newInput(`[A-Z]`, nil),
+		newInput(`\d{6}`, nil),
+		newInput(`\d`, nil),
+		newInput(`[A-Z]{2}`, nil),
+		newInput(`\d`, lengthErr),
+	})
+	if !errors.Is(err, lengthErr) || len(inputs) != 6 {
+		t.Fatalf("Validate() error = %v, want %v", err, lengthErr)
+	}
+	if lengthErr.Offset != 13 {
+		t.Errorf("Validate() offset = %d, want 13", lengthErr.Offset)
+	}
+}
@@ -0,0 +1,130 @@
+// Package x12 reads equipment details of ANSI X12 interchanges.
+package x12
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Segment is a segment with tag and data elements. Every data element has one or more components.
+type Segment struct {
+	Tag      string
+	Elements [][]string
+}
+
+// Value returns the component of the data element. Both start at 1 like in X12
+// references, e.g. Value(2, 1) is N702. An empty string is returned if the segment
+// has no such component.
+func (s Segment) Value(element, component int) string {
+	if element < 1 || element > len(s.Elements) || component < 1 || component > len(s.Elements[element-1]) {
+		return ""
+	}
+	return s.Elements[element-1][component-1]
+}
+
+// isaElementCount is the count of data elements of an ISA segment.
+const isaElementCount = 16
+
+// Parse returns the segments of an interchange. The element separator, component
+// separator and segment terminator are read from the ISA segment.
+func Parse(data string) ([]Segment, error) {
+	data = strings.TrimLeft(data, " \t\r\n")
+	if !strings.HasPrefix(data, "ISA") || len(data) < 4 {
+		return nil, errors.New("interchange does not start with ISA segment")
+	}
+	elementSep := data[3]
+
+	// The component separator is ISA16 and the segment terminator follows it.
+	pos := 3
+	for range isaElementCount - 1 {
+		next := strings.IndexByte(data[pos+1:], elementSep)
+		if next == -1 {
+			return nil, fmt.Errorf("ISA segment has not %d elements", isaElementCount)
+		}
+		pos += next + 1
+	}
+	if len(data) < pos+3 {
+		return nil, errors.New("ISA segment has no component separator and segment terminator")
+	}
+	componentSep := data[pos+1]
+	terminator := data[pos+2]
+
+	var segments []Segment
+	for _, raw := range strings.Split(data, string(terminator)) {
+		raw = strings.Trim(raw, "\r\n")
+		if raw == "" {
+			continue
+		}
+		elements := strings.Split(raw, string(elementSep))
+		segment := Segment{Tag: elements[0]}
+		if len(segment.Tag) < 2 || len(segment.Tag) > 3 {
+			return nil, fmt.Errorf("segment %s has no tag of 2 or 3 characters", raw)
+		}
+		for i, element := range elements[1:] {
+			// ISA16 is the component separator itself.
+			if segment.Tag == "ISA" && i == isaElementCount-1 {
+				segment.Elements = append(segment.Elements, []string{element})
+				continue
+			}
+			segment.Elements = append(segment.Elements, strings.Split(element, string(componentSep)))
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// Equipment is the equipment detail of an N7 segment.
+type Equipment struct {
+	// TransactionSet is the transaction set identifier code of the ST segment, e.g. 322.
+	TransactionSet string
+	// ControlNumber is the transaction set control number of the ST segment.
+	ControlNumber string
+	// SegmentNum is the position of the N7 segment in the transaction set. The ST segment is 1.
+	SegmentNum int
+	// ID is the equipment initial (N701) and equipment number (N702).
+	// The check digit (N718) is appended to an equipment number with 6 digits.
+	ID string
+	// Type is the equipment type (N722), e.g. 45G1.
+	Type string
+}
+
+// Equipments returns the equipment details of all N7 segments in the transaction sets of segments.
+// Equipments returns an error if an N7 segment is outside a transaction set.
+func Equipments(segments []Segment) ([]Equipment, error) {
+	var equipments []Equipment
+	transactionSet := ""
+	controlNumber := ""
+	segmentNum := 0
+	inTransactionSet := false
+	for _, segment := range segments {
+		switch segment.Tag {
+		case "ST":
+			transactionSet = segment.Value(1, 1)
+			controlNumber = segment.Value(2, 1)
+			segmentNum = 0
+			inTransactionSet = true
+		case "SE":
+			inTransactionSet = false
+		}
+		segmentNum++
+		if segment.Tag != "N7" {
+			continue
+		}
+		if !inTransactionSet {
+			return nil, errors.New("N7 segment is outside of a transaction set")
+		}
+		id := segment.Value(1, 1) + segment.Value(2, 1)
+		if len(segment.Value(2, 1)) == 6 {
+			id += segment.Value(18, 1)
+		}
+		equipments = append(equipments, Equipment{
+			TransactionSet: transactionSet,
+			ControlNumber:  controlNumber,
+			SegmentNum:     segmentNum,
+			ID:             id,
+			Type:           segment.Value(22, 1),
+		})
+	}
+	return equipments, nil
+}
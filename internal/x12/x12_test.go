@@ -0,0 +1,111 @@
+package x12
+
+import (
+	"reflect"
+	"testing"
+)
+
+const interchange = `ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       *240101*1200*U*00401*000000001*0*P*>~
+GS*SO*SENDER*RECEIVER*20240101*1200*1*X*004010~
+ST*322*0001~
+ZC1*REF*20240101~
+N7*ABCU*6813040~
+N7*ABCU*123123****************1****45G1~
+SE*5*0001~
+ST*315*0002~
+N7*ABCU*681304***************L*0~
+SE*3*0002~
+GE*2*1~
+IEA*1*000000001~
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Segment
+		wantErr bool
+	}{
+		{
+			"Separators of ISA",
+			"ISA|00|          |00|          |ZZ|SENDER         |ZZ|RECEIVER       |240101|1200|U|00401|000000001|0|P|:\n" +
+				"ST|322|0001\nN7|ABCU|6813040|a:b\n",
+			[]Segment{
+				{"ISA", [][]string{
+					{"00"}, {"          "}, {"00"}, {"          "}, {"ZZ"}, {"SENDER         "}, {"ZZ"}, {"RECEIVER       "},
+					{"240101"}, {"1200"}, {"U"}, {"00401"}, {"000000001"}, {"0"}, {"P"}, {":"},
+				}},
+				{"ST", [][]string{{"322"}, {"0001"}}},
+				{"N7", [][]string{{"ABCU"}, {"6813040"}, {"a", "b"}}},
+			},
+			false,
+		},
+		{
+			"No ISA segment",
+			"ST*322*0001~",
+			nil,
+			true,
+		},
+		{
+			"Too few ISA elements",
+			"ISA*00*          *00~",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEquipments(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Equipment
+		wantErr bool
+	}{
+		{
+			"Equipments of multiple transaction sets",
+			interchange,
+			[]Equipment{
+				{"322", "0001", 3, "ABCU6813040", ""},
+				{"322", "0001", 4, "ABCU1231231", "45G1"},
+				{"315", "0002", 2, "ABCU6813040", ""},
+			},
+			false,
+		},
+		{
+			"Equipment outside of transaction set",
+			"ISA*00*          *00*          *ZZ*SENDER         *ZZ*RECEIVER       *240101*1200*U*00401*000000001*0*P*>~" +
+				"N7*ABCU*6813040~",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := Parse(tt.data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, err := Equipments(segments)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Equipments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Equipments() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
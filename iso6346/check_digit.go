@@ -1,4 +1,4 @@
-package cont
+package iso6346
 
 // CalcCheckDigit calculates check digit for owner, equipment category ID and serial number.
 // This function was optimized for fun and has a suboptimal reading experience.
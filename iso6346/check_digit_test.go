@@ -1,4 +1,4 @@
-package cont
+package iso6346
 
 import (
 	"fmt"
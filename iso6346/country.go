@@ -0,0 +1,24 @@
+package iso6346
+
+import "fmt"
+
+// Country has a legacy country code and the name of the country. Before ISO 6346:1995
+// the country code was marked between check digit and size and type code, e.g. DE in
+// ABCU 123456 0 DE 22G1.
+type Country struct {
+	Code string
+	Name string
+}
+
+// IsCountryCode checks if string is two upper case letters.
+func IsCountryCode(code string) error {
+	if len(code) != 2 {
+		return NewFieldError(ErrorKindBadLength, FieldCountryCode, "2",
+			fmt.Sprintf("%s is not 2 letters long", code))
+	}
+	if !isUpperLetter(code) {
+		return NewFieldError(ErrorKindBadFormat, FieldCountryCode, "",
+			fmt.Sprintf("%s is not 2 upper case letters", code))
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+// Package iso6346 implements the rules of ISO 6346 for intermodal container markings.
+//
+// The package calculates check digits, validates owner codes, equipment category IDs
+// and size and type codes, finds error-prone serial numbers and generates unique
+// container numbers. It has no dependencies to the icm command line tool and can be
+// imported by other programs:
+//
+//	checkDigit := iso6346.CalcCheckDigit("ABC", 'U', 123456)
+//
+// Exported identifiers follow semantic versioning of the module github.com/mrclmr/icm.
+package iso6346
@@ -1,4 +1,4 @@
-package cont
+package iso6346
 
 import "fmt"
 
@@ -21,10 +21,12 @@ func NewEquipCatID(id string, info string) EquipCat {
 // IsEquipCatID checks if string is one upper case letter.
 func IsEquipCatID(ID string) error {
 	if len(ID) != 1 {
-		return NewValidateError(fmt.Sprintf("%s is not 1 letter long", ID))
+		return NewFieldError(ErrorKindBadLength, FieldEquipCatID, "1",
+			fmt.Sprintf("%s is not 1 letter long", ID))
 	}
 	if !isUpperLetter(ID) {
-		return NewValidateError(fmt.Sprintf("%s is not 1 upper case letter", ID))
+		return NewFieldError(ErrorKindBadFormat, FieldEquipCatID, "",
+			fmt.Sprintf("%s is not 1 upper case letter", ID))
 	}
 	return nil
 }
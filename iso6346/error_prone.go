@@ -0,0 +1,116 @@
+package iso6346
+
+// ErrorClass is a class of keying errors.
+type ErrorClass string
+
+// Classes of keying errors.
+const (
+	// ErrorClassTransposition swaps two adjacent digits, e.g. 12 → 21.
+	ErrorClassTransposition ErrorClass = "transposition"
+	// ErrorClassJumpTransposition swaps two digits around a digit, e.g. 123 → 321.
+	ErrorClassJumpTransposition ErrorClass = "jump-transposition"
+	// ErrorClassTwin replaces two adjacent equal digits, e.g. 11 → 22.
+	ErrorClassTwin ErrorClass = "twin"
+	// ErrorClassSubstitution replaces a single digit, e.g. 1 → 2. A substitution
+	// only results in a valid container number because check digit 10 is written as 0.
+	ErrorClassSubstitution ErrorClass = "substitution"
+)
+
+// ErrorProneNumber represents a valid container number that results from
+// a keying error of another container number.
+type ErrorProneNumber struct {
+	Number
+	// Class is the class of the keying error.
+	Class ErrorClass
+	// Pos is the position of the first changed digit starting with 0.
+	// The serial number has the positions 0 to 5 and the check digit has position 6.
+	Pos int
+}
+
+// CheckErrorProne returns an array of ErrorProneNumber's for error-prone serial numbers.
+// The digits of serial number and check digit are changed by every ErrorClass and
+// every resulting valid container number is returned. The result is ordered by
+// ErrorClass, position and digit.
+// CheckErrorProne returns nil if no error-prone serial number is found.
+func CheckErrorProne(ownerCode string, equipCatID rune, serialNum int, checkDigit int) []ErrorProneNumber {
+	digits := [7]int{}
+	digits[6] = checkDigit % 10
+	for i, s := 5, serialNum; i >= 0; i, s = i-1, s/10 {
+		digits[i] = s % 10
+	}
+
+	var numbers []ErrorProneNumber
+	check := func(class ErrorClass, pos int, changed [7]int) {
+		serial := 0
+		for _, digit := range changed[:6] {
+			serial = serial*10 + digit
+		}
+		if CalcCheckDigit(ownerCode, equipCatID, serial)%10 == changed[6] {
+			numbers = append(numbers, ErrorProneNumber{Number{ownerCode, equipCatID, serial, changed[6]}, class, pos})
+		}
+	}
+
+	for pos := 0; pos < 6; pos++ {
+		if digits[pos] == digits[pos+1] {
+			continue
+		}
+		changed := digits
+		changed[pos], changed[pos+1] = changed[pos+1], changed[pos]
+		check(ErrorClassTransposition, pos, changed)
+	}
+
+	for pos := 0; pos < 5; pos++ {
+		if digits[pos] == digits[pos+2] {
+			continue
+		}
+		changed := digits
+		changed[pos], changed[pos+2] = changed[pos+2], changed[pos]
+		check(ErrorClassJumpTransposition, pos, changed)
+	}
+
+	for pos := 0; pos < 6; pos++ {
+		if digits[pos] != digits[pos+1] {
+			continue
+		}
+		for digit := 0; digit < 10; digit++ {
+			if digit == digits[pos] {
+				continue
+			}
+			changed := digits
+			changed[pos], changed[pos+1] = digit, digit
+			check(ErrorClassTwin, pos, changed)
+		}
+	}
+
+	// A substituted check digit never results in a valid container number.
+	for pos := 0; pos < 6; pos++ {
+		for digit := 0; digit < 10; digit++ {
+			if digit == digits[pos] {
+				continue
+			}
+			changed := digits
+			changed[pos] = digit
+			check(ErrorClassSubstitution, pos, changed)
+		}
+	}
+	return numbers
+}
+
+// CheckDigit10Collisions returns container numbers that differ from the passed
+// container number by one substituted serial number digit and that are still valid,
+// because check digit 10 is written as 0. Only container numbers with calculated
+// check digit 0 or 10 have collisions.
+// CheckDigit10Collisions returns nil if no collision is found.
+func CheckDigit10Collisions(ownerCode string, equipCatID rune, serialNum int) []Number {
+	checkDigit := CalcCheckDigit(ownerCode, equipCatID, serialNum)
+	if checkDigit%10 != 0 {
+		return nil
+	}
+	var numbers []Number
+	for _, epn := range CheckErrorProne(ownerCode, equipCatID, serialNum, checkDigit) {
+		if epn.Class == ErrorClassSubstitution {
+			numbers = append(numbers, epn.Number)
+		}
+	}
+	return numbers
+}
@@ -0,0 +1,145 @@
+package iso6346
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestCheckErrorProne(t *testing.T) {
+	tests := []struct {
+		ownerCode  string
+		equipCatID rune
+		serialNum  int
+		checkDigit int
+		want       []ErrorProneNumber
+	}{
+		{
+			ownerCode:  "ABC",
+			equipCatID: 'U',
+			serialNum:  123123,
+			checkDigit: 7,
+			want:       nil,
+		},
+		{
+			ownerCode:  "CMA",
+			equipCatID: 'U',
+			serialNum:  163912,
+			checkDigit: 10,
+			want: []ErrorProneNumber{
+				{Number{"CMA", 'U', 169312, 0}, ErrorClassTransposition, 2},
+				{Number{"CMA", 'U', 163192, 0}, ErrorClassTransposition, 3},
+				{Number{"CMA", 'U', 153912, 0}, ErrorClassSubstitution, 1},
+				{Number{"CMA", 'U', 168912, 0}, ErrorClassSubstitution, 2},
+				{Number{"CMA", 'U', 163612, 0}, ErrorClassSubstitution, 3},
+				{Number{"CMA", 'U', 163952, 0}, ErrorClassSubstitution, 4},
+				{Number{"CMA", 'U', 163914, 0}, ErrorClassSubstitution, 5},
+			},
+		},
+		{
+			ownerCode:  "ABC",
+			equipCatID: 'U',
+			serialNum:  11,
+			checkDigit: 10,
+			want: []ErrorProneNumber{
+				{Number{"ABC", 'U', 110, 0}, ErrorClassJumpTransposition, 3},
+				{Number{"ABC", 'U', 330011, 0}, ErrorClassTwin, 0},
+				{Number{"ABC", 'U', 77011, 0}, ErrorClassTwin, 1},
+				{Number{"ABC", 'U', 9911, 0}, ErrorClassTwin, 2},
+				{Number{"ABC", 'U', 66, 0}, ErrorClassTwin, 4},
+				{Number{"ABC", 'U', 900011, 0}, ErrorClassSubstitution, 0},
+				{Number{"ABC", 'U', 5011, 0}, ErrorClassSubstitution, 2},
+				{Number{"ABC", 'U', 811, 0}, ErrorClassSubstitution, 3},
+				{Number{"ABC", 'U', 51, 0}, ErrorClassSubstitution, 4},
+				{Number{"ABC", 'U', 13, 0}, ErrorClassSubstitution, 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("%s %s %06d %d", tt.ownerCode, string(tt.equipCatID), tt.serialNum, tt.checkDigit),
+			func(t *testing.T) {
+				if got := CheckErrorProne(tt.ownerCode, tt.equipCatID, tt.serialNum, tt.checkDigit); !slices.Equal(got, tt.want) {
+					t.Errorf("CheckErrorProne() = %v, want %v", got, tt.want)
+				}
+			})
+	}
+}
+
+func TestCheckErrorProneTransposition(t *testing.T) {
+	for serialNum := 0; serialNum < 1000000; serialNum += 7 {
+		checkDigit := CalcCheckDigit("APL", 'U', serialNum)
+
+		var got []TpNumber
+		for _, n := range CheckErrorProne("APL", 'U', serialNum, checkDigit) {
+			if n.Class == ErrorClassTransposition {
+				got = append(got, TpNumber{n.Number, n.Pos})
+			}
+		}
+		if want := CheckTransposition("APL", 'U', serialNum, checkDigit); !slices.Equal(got, want) {
+			t.Fatalf("CheckErrorProne() transpositions of %06d = %v, want %v", serialNum, got, want)
+		}
+	}
+}
+
+func BenchmarkCheckErrorProne(b *testing.B) {
+	for b.Loop() {
+		CheckErrorProne("APL", 'U', 689473, 10)
+	}
+}
+
+func TestCheckDigit10Collisions(t *testing.T) {
+	tests := []struct {
+		ownerCode  string
+		equipCatID rune
+		serialNum  int
+		want       []Number
+	}{
+		{
+			ownerCode:  "ABC",
+			equipCatID: 'U',
+			serialNum:  123123,
+			want:       nil,
+		},
+		{
+			ownerCode:  "CMA",
+			equipCatID: 'U',
+			serialNum:  163912,
+			want: []Number{
+				{"CMA", 'U', 153912, 0},
+				{"CMA", 'U', 168912, 0},
+				{"CMA", 'U', 163612, 0},
+				{"CMA", 'U', 163952, 0},
+				{"CMA", 'U', 163914, 0},
+			},
+		},
+		{
+			ownerCode:  "ABC",
+			equipCatID: 'U',
+			serialNum:  123456,
+			want: []Number{
+				{"ABC", 'U', 323456, 0},
+				{"ABC", 'U', 133456, 0},
+				{"ABC", 'U', 129456, 0},
+				{"ABC", 'U', 123756, 0},
+				{"ABC", 'U', 123416, 0},
+				{"ABC", 'U', 123454, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("%s %s %06d", tt.ownerCode, string(tt.equipCatID), tt.serialNum),
+			func(t *testing.T) {
+				got := CheckDigit10Collisions(tt.ownerCode, tt.equipCatID, tt.serialNum)
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("CheckDigit10Collisions() = %v, want %v", got, tt.want)
+				}
+				for _, n := range got {
+					if calc := CalcCheckDigit(n.OwnerCode, n.EquipCatID, n.SerialNumber); calc%10 != 0 {
+						t.Errorf("CalcCheckDigit() of %v = %d, want 0 or 10", n, calc)
+					}
+				}
+			})
+	}
+}
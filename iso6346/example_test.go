@@ -0,0 +1,78 @@
+package iso6346_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/mrclmr/icm/iso6346"
+)
+
+func ExampleCalcCheckDigit() {
+	checkDigit := iso6346.CalcCheckDigit("CSQ", 'U', 305438)
+	fmt.Println(checkDigit)
+	// Output: 3
+}
+
+func ExampleCheckTransposition() {
+	for _, tn := range iso6346.CheckTransposition("RCB", 'U', 1130, 0) {
+		fmt.Printf("%s %c %06d %d\n", tn.OwnerCode, tn.EquipCatID, tn.SerialNumber, tn.CheckDigit)
+	}
+	// Output: RCB U 010130 0
+}
+
+func ExampleCheckErrorProne() {
+	for _, epn := range iso6346.CheckErrorProne("ABC", 'U', 11, 10) {
+		fmt.Printf("%s %s\n", epn.Format(iso6346.Layout{SepOE: " ", SepES: " ", SepSC: " "}), epn.Class)
+	}
+	// Output:
+	// ABC U 000110 0 jump-transposition
+	// ABC U 330011 0 twin
+	// ABC U 077011 0 twin
+	// ABC U 009911 0 twin
+	// ABC U 000066 0 twin
+	// ABC U 900011 0 substitution
+	// ABC U 005011 0 substitution
+	// ABC U 000811 0 substitution
+	// ABC U 000051 0 substitution
+	// ABC U 000013 0 substitution
+}
+
+func ExampleNewUniqueGeneratorBuilder() {
+	generator, err := iso6346.NewUniqueGeneratorBuilder(rand.New(rand.NewPCG(1, 0))).
+		OwnerCodes([]string{"ABC"}).
+		Start(100500).
+		Count(3).
+		Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for generator.Generate() {
+		cn := generator.ContNum()
+		fmt.Printf("%s %c %06d %d\n", cn.OwnerCode, cn.EquipCatID, cn.SerialNumber, cn.CheckDigit)
+	}
+	// Output:
+	// ABC U 100500 8
+	// ABC U 100501 3
+	// ABC U 100502 9
+}
+
+func ExampleGeneratorBuilder_ILUOwnerKeys() {
+	generator, err := iso6346.NewUniqueGeneratorBuilder(rand.New(rand.NewPCG(1, 0))).
+		ILUOwnerKeys([]string{"ABCA"}).
+		Start(100500).
+		Count(3).
+		Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for generator.Generate() {
+		cn := generator.ContNum()
+		fmt.Printf("%s %c %06d %d\n", cn.OwnerCode, cn.EquipCatID, cn.SerialNumber, cn.CheckDigit)
+	}
+	// Output:
+	// ABC A 100500 8
+	// ABC A 100501 3
+	// ABC A 100502 9
+}
@@ -0,0 +1,42 @@
+package iso6346
+
+import (
+	"regexp"
+	"strings"
+)
+
+// numberRegexp matches container numbers in text. A space, tab, dot, slash or hyphen
+// is allowed as separator between owner code, equipment category ID, serial number and check digit.
+var numberRegexp = regexp.MustCompile(`\b[A-Za-z]{3}[ \t./-]?[UJZujz][ \t./-]?\d{6}[ \t./-]?\d\b`)
+
+// NumberMatch is a container number found by FindNumbers.
+type NumberMatch struct {
+	Number
+	// Text is the matched text including separators.
+	Text string
+	// Offset is the byte offset of Text in the searched text.
+	Offset int
+	// Err is the error of ValidateCheckDigit or nil if the check digit is valid.
+	Err error
+}
+
+// FindNumbers returns all container numbers in s, e.g. in the text of an email.
+// Owner code and equipment category ID are matched in upper and lower case
+// and are upper case in NumberMatch.Number. Every match is validated, see NumberMatch.Err.
+func FindNumbers(s string) []NumberMatch {
+	var matches []NumberMatch
+	for _, loc := range numberRegexp.FindAllStringIndex(s, -1) {
+		text := s[loc[0]:loc[1]]
+		normalized := strings.ToUpper(firstAlphanumeric(text, 11))
+		serialNum, _ := parseDigits(normalized[4:10])
+		checkDigit, _ := parseDigits(normalized[10:11])
+		n := Number{normalized[0:3], rune(normalized[3]), serialNum, checkDigit}
+		matches = append(matches, NumberMatch{
+			Number: n,
+			Text:   text,
+			Offset: loc[0],
+			Err:    ValidateCheckDigit(n, false),
+		})
+	}
+	return matches
+}
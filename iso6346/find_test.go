@@ -0,0 +1,62 @@
+package iso6346
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindNumbers(t *testing.T) {
+	type want struct {
+		number    Number
+		text      string
+		offset    int
+		errorKind ErrorKind
+	}
+	tests := []struct {
+		name string
+		s    string
+		want []want
+	}{
+		{
+			"Numbers with different separators",
+			"Please check ABCU6813040, ABC U 681304-0 and ABCU 681304/1.",
+			[]want{
+				{Number{"ABC", 'U', 681304, 0}, "ABCU6813040", 13, ""},
+				{Number{"ABC", 'U', 681304, 0}, "ABC U 681304-0", 26, ""},
+				{Number{"ABC", 'U', 681304, 1}, "ABCU 681304/1", 45, ErrorKindCheckDigitMismatch},
+			},
+		},
+		{
+			"Numbers in lower case",
+			"abcu6813040 and Abc u 681304 0",
+			[]want{
+				{Number{"ABC", 'U', 681304, 0}, "abcu6813040", 0, ""},
+				{Number{"ABC", 'U', 681304, 0}, "Abc u 681304 0", 16, ""},
+			},
+		},
+		{
+			"No numbers in words or wrong equipment category ID",
+			"XABCU6813040 ABCX6813040 ABCU68130401",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindNumbers(tt.s)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FindNumbers() = %v, want %v", got, tt.want)
+			}
+			for i, w := range tt.want {
+				if got[i].Number != w.number || got[i].Text != w.text || got[i].Offset != w.offset {
+					t.Errorf("FindNumbers()[%d] = %v %q %d, want %v %q %d",
+						i, got[i].Number, got[i].Text, got[i].Offset, w.number, w.text, w.offset)
+				}
+				var validateErr *ValidateError
+				if w.errorKind == "" && got[i].Err != nil ||
+					w.errorKind != "" && (!errors.As(got[i].Err, &validateErr) || validateErr.Kind != w.errorKind) {
+					t.Errorf("FindNumbers()[%d].Err = %v, want kind %q", i, got[i].Err, w.errorKind)
+				}
+			}
+		})
+	}
+}
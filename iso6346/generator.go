@@ -1,4 +1,4 @@
-package cont
+package iso6346
 
 import (
 	"errors"
@@ -10,7 +10,7 @@ import (
 // Use NewUniqueGeneratorBuilder to create a new one.
 type GeneratorBuilder struct {
 	rand                        *rand.Rand
-	codes                       []string
+	owners                      []generatorOwner
 	count                       int
 	start                       int
 	end                         int
@@ -30,9 +30,33 @@ func NewUniqueGeneratorBuilder(rand *rand.Rand) *GeneratorBuilder {
 	}
 }
 
-// OwnerCodes sets the owner codes for generation.
+// generatorOwner is an owner code with the equipment category ID of the generated numbers.
+type generatorOwner struct {
+	code       string
+	equipCatID rune
+}
+
+// OwnerCodes sets the owner codes for generation with equipment category ID U.
+// It replaces owner keys set by ILUOwnerKeys.
 func (gb *GeneratorBuilder) OwnerCodes(codes []string) *GeneratorBuilder {
-	gb.codes = codes
+	gb.owners = nil
+	for _, code := range codes {
+		gb.owners = append(gb.owners, generatorOwner{code: code, equipCatID: 'U'})
+	}
+	return gb
+}
+
+// ILUOwnerKeys sets the owner keys of intermodal loading units (ILU) for generation instead of owner codes.
+// The last letter of an owner key, e.g. A of ABCA, is used as equipment category ID.
+// It replaces owner codes set by OwnerCodes. Owner keys that are not 4 letters long are ignored.
+func (gb *GeneratorBuilder) ILUOwnerKeys(keys []string) *GeneratorBuilder {
+	gb.owners = nil
+	for _, key := range keys {
+		if len(key) != 4 {
+			continue
+		}
+		gb.owners = append(gb.owners, generatorOwner{code: key[:3], equipCatID: rune(key[3])})
+	}
 	return gb
 }
 
@@ -61,6 +85,7 @@ func (gb *GeneratorBuilder) ExcludeCheckDigit10(exclude bool) *GeneratorBuilder
 }
 
 // ExcludeErrorProneSerialNumbers sets the exclusion of container numbers with error-prone serial numbers.
+// See CheckErrorProne for the checked keying errors.
 func (gb *GeneratorBuilder) ExcludeErrorProneSerialNumbers(exclude bool) *GeneratorBuilder {
 	gb.exclErrorProneSerialNumbers = exclude
 	return gb
@@ -72,9 +97,9 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 		return nil, fmt.Errorf("count %d is lower than minimum count 1", gb.count)
 	}
 
-	lenCodes := len(gb.codes)
+	lenOwners := len(gb.owners)
 
-	if lenCodes < 1 {
+	if lenOwners < 1 {
 		return nil, errors.New("cannot generate container numbers without owner codes")
 	}
 
@@ -84,9 +109,9 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 		serialNums = 909091
 	}
 
-	if gb.count > lenCodes*serialNums {
+	if gb.count > lenOwners*serialNums {
 		return nil, fmt.Errorf("count %d exceeds limit of %d (%d owners * %d serial numbers)",
-			gb.count, lenCodes*serialNums, lenCodes, serialNums)
+			gb.count, lenOwners*serialNums, lenOwners, serialNums)
 	}
 
 	var sni serialNumIt
@@ -113,13 +138,14 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 		count = gb.count
 	}
 
-	gb.rand.Shuffle(lenCodes, func(i, j int) {
-		gb.codes[i], gb.codes[j] = gb.codes[j], gb.codes[i]
+	gb.rand.Shuffle(lenOwners, func(i, j int) {
+		gb.owners[i], gb.owners[j] = gb.owners[j], gb.owners[i]
 	})
 
 	return &UniqueGenerator{
-		codes:                       gb.codes,
-		lenCodes:                    lenCodes,
+		owners:                      gb.owners,
+		lenOwners:                   lenOwners,
+		limit:                       lenOwners * 1000000,
 		serialNumIt:                 sni,
 		count:                       count,
 		exclCheckDigit10:            gb.exclCheckDigit10,
@@ -130,44 +156,65 @@ func (gb *GeneratorBuilder) Build() (*UniqueGenerator, error) {
 // UniqueGenerator holds state for generating random unique container numbers.
 // Use NewUniqueGeneratorBuilder for initialization.
 type UniqueGenerator struct {
-	codes                       []string
-	lenCodes                    int
+	owners                      []generatorOwner
+	lenOwners                   int
 	ownerOffset                 int
 	serialNumIt                 serialNumIt
 	count                       int
+	limit                       int
 	contNum                     Number
 	generatedCount              int
+	iteratedCount               int
 	exclCheckDigit10            bool
 	exclErrorProneSerialNumbers bool
+	err                         error
 }
 
 // Generate advances the serial number iterator to the next serial number,
 // which will then be available through the ContNum method. It returns false
-// when the generation stops by reaching the count of generated container numbers.
+// when the generation stops by reaching the count of generated container numbers
+// or when all container numbers are generated because too many serial numbers
+// are excluded. In the latter case Err returns an error.
 func (g *UniqueGenerator) Generate() bool {
 	if g.generatedCount == g.count {
 		return false
 	}
 
-	serialNum := g.serialNumIt.num()
-	code := g.codes[(serialNum+g.ownerOffset)%g.lenCodes]
-	checkDigit := CalcCheckDigit(code, 'U', serialNum)
+	for {
+		if g.iteratedCount == g.limit {
+			g.err = fmt.Errorf("count %d exceeds limit of %d container numbers without excluded serial numbers",
+				g.count, g.generatedCount)
+			return false
+		}
+		g.iteratedCount++
 
-	if g.serialNumIt.isLast() {
-		g.ownerOffset++
-	}
-	g.serialNumIt.increment()
+		serialNum := g.serialNumIt.num()
+		owner := g.owners[(serialNum+g.ownerOffset)%g.lenOwners]
+		code, equipCatID := owner.code, owner.equipCatID
+		checkDigit := CalcCheckDigit(code, equipCatID, serialNum)
 
-	if g.exclCheckDigit10 && checkDigit == 10 {
-		return g.Generate()
-	}
-	if g.exclErrorProneSerialNumbers && CheckTransposition(code, 'U', serialNum, checkDigit) != nil {
-		return g.Generate()
+		if g.serialNumIt.isLast() {
+			g.ownerOffset++
+		}
+		g.serialNumIt.increment()
+
+		if g.exclCheckDigit10 && checkDigit == 10 {
+			continue
+		}
+		if g.exclErrorProneSerialNumbers && CheckErrorProne(code, equipCatID, serialNum, checkDigit) != nil {
+			continue
+		}
+		g.contNum = Number{code, equipCatID, serialNum, checkDigit % 10}
+		g.generatedCount++
+
+		return true
 	}
-	g.contNum = Number{code, 'U', serialNum, checkDigit % 10}
-	g.generatedCount++
+}
 
-	return true
+// Err returns the error that stopped the generation or nil
+// if the count of generated container numbers is reached.
+func (g *UniqueGenerator) Err() error {
+	return g.err
 }
 
 // ContNum returns a generated container number.
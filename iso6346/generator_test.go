@@ -1,4 +1,4 @@
-package cont
+package iso6346
 
 import (
 	"fmt"
@@ -31,8 +31,9 @@ func TestGeneratorBuilder(t *testing.T) {
 				true,
 			},
 			&UniqueGenerator{
-				codes:    []string{"ABC"},
-				lenCodes: 1,
+				owners:    []generatorOwner{{"ABC", 'U'}},
+				lenOwners: 1,
+				limit:     1000000,
 				serialNumIt: &randSerialNumIt{
 					randOffset: 1812594575390091523,
 				},
@@ -50,8 +51,9 @@ func TestGeneratorBuilder(t *testing.T) {
 				rangeEnd:   -1,
 			},
 			&UniqueGenerator{
-				codes:       []string{"ABC"},
-				lenCodes:    1,
+				owners:      []generatorOwner{{"ABC", 'U'}},
+				lenOwners:   1,
+				limit:       1000000,
 				serialNumIt: newSeqSerialNumIt(2),
 				count:       3,
 			},
@@ -66,8 +68,9 @@ func TestGeneratorBuilder(t *testing.T) {
 				rangeEnd:   2,
 			},
 			&UniqueGenerator{
-				codes:       []string{"ABC"},
-				lenCodes:    1,
+				owners:      []generatorOwner{{"ABC", 'U'}},
+				lenOwners:   1,
+				limit:       1000000,
 				serialNumIt: newSeqSerialNumIt(-1),
 				count:       4,
 			},
@@ -82,8 +85,9 @@ func TestGeneratorBuilder(t *testing.T) {
 				rangeEnd:   5,
 			},
 			&UniqueGenerator{
-				codes:       []string{"ABC"},
-				lenCodes:    1,
+				owners:      []generatorOwner{{"ABC", 'U'}},
+				lenOwners:   1,
+				limit:       1000000,
 				serialNumIt: newSeqSerialNumIt(2),
 				count:       4,
 			},
@@ -214,6 +218,15 @@ func TestUniqueGenerator(t *testing.T) {
 			false,
 			1,
 		},
+		{
+			"Generate 3 unique ILU codes with sequential serial numbers",
+			NewUniqueGeneratorBuilder(r).
+				ILUOwnerKeys([]string{"ABCA"}).
+				Count(3).
+				Start(1),
+			true,
+			3,
+		},
 		{
 			"Generate 2000001 unique container numbers with random serial numbers",
 			NewUniqueGeneratorBuilder(r).
@@ -262,3 +275,54 @@ func TestUniqueGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratorBuilder_ILUOwnerKeys(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 0))
+	g, err := NewUniqueGeneratorBuilder(r).
+		ILUOwnerKeys([]string{"ABCA"}).
+		Start(1).
+		Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	g.Generate()
+	if got := g.ContNum(); got.OwnerCode != "ABC" || got.EquipCatID != 'A' {
+		t.Errorf("UniqueGenerator.ContNum() = %v, want owner code ABC and equipment category ID A", got)
+	}
+
+	g, err = NewUniqueGeneratorBuilder(r).
+		ILUOwnerKeys([]string{"ABCA"}).
+		OwnerCodes([]string{"ABCD"}).
+		Start(1).
+		Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+	g.Generate()
+	if got := g.ContNum(); got.OwnerCode != "ABCD" || got.EquipCatID != 'U' {
+		t.Errorf("UniqueGenerator.ContNum() = %v, want owner code ABCD and equipment category ID U", got)
+	}
+}
+
+func TestUniqueGenerator_Err(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 0))
+	g, err := NewUniqueGeneratorBuilder(r).
+		OwnerCodes([]string{"AAA"}).
+		Count(1000000).
+		ExcludeErrorProneSerialNumbers(true).
+		Build()
+	if err != nil {
+		t.Fatalf("GeneratorBuilder.Build() error = %v", err)
+	}
+
+	contNumbers := map[Number]bool{}
+	for g.Generate() {
+		contNumbers[g.ContNum()] = true
+	}
+	if g.Err() == nil {
+		t.Errorf("UniqueGenerator.Err() = nil, want error")
+	}
+	if got := len(contNumbers); got != g.generatedCount {
+		t.Errorf("UniqueGenerator.Generate() generated %v unique of %v container numbers", got, g.generatedCount)
+	}
+}
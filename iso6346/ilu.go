@@ -0,0 +1,40 @@
+package iso6346
+
+import (
+	"fmt"
+	"strings"
+)
+
+// iluEquipCatIDs are the letters that end the owner key of an intermodal loading unit (ILU).
+const iluEquipCatIDs = "ABCDK"
+
+// ILUEquipCatIDs returns the letters A, B, C, D and K that end the owner key of
+// an intermodal loading unit (ILU) like a swap body or semi-trailer according to EN 13044.
+// ILU codes are calculated with CalcCheckDigit like container numbers.
+func ILUEquipCatIDs() []string {
+	return strings.Split(iluEquipCatIDs, "")
+}
+
+// IsILUEquipCatID checks if string is one of the letters A, B, C, D or K.
+func IsILUEquipCatID(ID string) error {
+	if err := IsEquipCatID(ID); err != nil {
+		return err
+	}
+	if !strings.Contains(iluEquipCatIDs, ID) {
+		return NewFieldError(ErrorKindUnknownEquipCatID, FieldEquipCatID, "A, B, C, D, K",
+			fmt.Sprintf("%s is not A, B, C, D or K", ID))
+	}
+	return nil
+}
+
+// IsILUOwnerKey checks if string is three upper case letters followed by A, B, C, D or K.
+func IsILUOwnerKey(key string) error {
+	if len(key) != 4 {
+		return NewFieldError(ErrorKindBadLength, FieldOwnerCode, "4",
+			fmt.Sprintf("%s is not 4 letters long", key))
+	}
+	if err := IsOwnerCode(key[:3]); err != nil {
+		return err
+	}
+	return IsILUEquipCatID(key[3:])
+}
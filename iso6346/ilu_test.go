@@ -0,0 +1,25 @@
+package iso6346
+
+import "testing"
+
+func TestIsILUOwnerKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"Owner key with A", "ABCA", false},
+		{"Owner key with K", "ABCK", false},
+		{"Owner key with container equipment category ID", "ABCU", true},
+		{"Owner key with lower case letters", "abcA", true},
+		{"Owner key too short", "ABC", true},
+		{"Owner key too long", "ABCDA", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := IsILUOwnerKey(tt.key); (err != nil) != tt.wantErr {
+				t.Errorf("IsILUOwnerKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
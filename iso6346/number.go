@@ -0,0 +1,193 @@
+package iso6346
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Number is a container number with needed properties to conform to the specified standard.
+type Number struct {
+	OwnerCode    string
+	EquipCatID   rune
+	SerialNumber int
+	CheckDigit   int
+}
+
+// Layout has the separators that are used by Number.Format.
+type Layout struct {
+	// SepOE separates owner code and equipment category ID.
+	SepOE string
+	// SepES separates equipment category ID and serial number.
+	SepES string
+	// SepSC separates serial number and check digit.
+	SepSC string
+}
+
+// ParseNumber parses a container number like "ABCU1234560", "abc u 123456-0" or "ABCU 123456 0".
+// Every character that is not an ASCII letter or digit is treated as a separator.
+// ParseNumber returns an error if the format is invalid or the check digit is not correct.
+func ParseNumber(s string) (Number, error) {
+	b := strings.Builder{}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(unicode.ToUpper(r))
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+	normalized := b.String()
+
+	if len(normalized) != 11 {
+		return Number{}, NewFieldError(ErrorKindBadLength, "", "11",
+			fmt.Sprintf("%s is not 11 characters long", normalized))
+	}
+
+	ownerCode := normalized[0:3]
+	if err := IsOwnerCode(ownerCode); err != nil {
+		return Number{}, err
+	}
+
+	equipCatID := normalized[3:4]
+	if err := IsEquipCatID(equipCatID); err != nil {
+		return Number{}, err
+	}
+
+	serialNum, ok := parseDigits(normalized[4:10])
+	if !ok {
+		return Number{}, NewFieldError(ErrorKindBadFormat, FieldSerialNumber, "",
+			fmt.Sprintf("%s is not 6 digits", normalized[4:10]))
+	}
+
+	checkDigit, ok := parseDigits(normalized[10:11])
+	if !ok {
+		return Number{}, NewFieldError(ErrorKindBadFormat, FieldCheckDigit, "",
+			fmt.Sprintf("%s is not 1 digit", normalized[10:11]))
+	}
+
+	n := Number{ownerCode, rune(equipCatID[0]), serialNum, checkDigit}
+	if err := ValidateCheckDigit(n, false); err != nil {
+		return Number{}, err
+	}
+	return n, nil
+}
+
+// ValidateCheckDigit returns a ValidateError of kind ErrorKindCheckDigitMismatch
+// if the check digit of n is not the calculated check digit.
+// If strict is true a ValidateError of kind ErrorKindCheckDigit10 is returned for a
+// calculated check digit 10, because ISO 6346 recommends not to use such serial numbers.
+func ValidateCheckDigit(n Number, strict bool) error {
+	calc := CalcCheckDigit(n.OwnerCode, n.EquipCatID, n.SerialNumber)
+	if calc%10 != n.CheckDigit {
+		return NewFieldError(ErrorKindCheckDigitMismatch, FieldCheckDigit, strconv.Itoa(calc%10),
+			fmt.Sprintf("check digit of %s is %d but calculated check digit is %d", n, n.CheckDigit, calc%10))
+	}
+	if strict && calc == 10 {
+		return NewFieldError(ErrorKindCheckDigit10, FieldCheckDigit, "",
+			fmt.Sprintf("calculated check digit of %s is 10", n))
+	}
+	return nil
+}
+
+func parseDigits(s string) (int, bool) {
+	number := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+		number = number*10 + int(r-'0')
+	}
+	return number, true
+}
+
+// IsZero returns true if n is the zero value.
+func (n Number) IsZero() bool {
+	return n == Number{}
+}
+
+// String returns the container number without separators, e.g. "ABCU1234560".
+// The zero value returns an empty string.
+func (n Number) String() string {
+	return n.Format(Layout{})
+}
+
+// Format returns the container number with the separators of layout.
+// The zero value returns an empty string.
+func (n Number) Format(layout Layout) string {
+	if n.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s%s%c%s%06d%s%d",
+		n.OwnerCode, layout.SepOE,
+		n.EquipCatID, layout.SepES,
+		n.SerialNumber, layout.SepSC,
+		n.CheckDigit)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n Number) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Empty text results in the zero value.
+func (n *Number) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = Number{}
+		return nil
+	}
+	parsed, err := ParseNumber(string(text))
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The zero value is marshaled to null.
+func (n Number) MarshalJSON() ([]byte, error) {
+	if n.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null results in the zero value.
+func (n *Number) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*n = Number{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return n.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner. NULL results in the zero value.
+func (n *Number) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*n = Number{}
+		return nil
+	case string:
+		return n.UnmarshalText([]byte(v))
+	case []byte:
+		return n.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into container number", src)
+	}
+}
+
+// Value implements driver.Valuer. The zero value is stored as NULL.
+func (n Number) Value() (driver.Value, error) {
+	if n.IsZero() {
+		return nil, nil
+	}
+	return n.String(), nil
+}
@@ -0,0 +1,232 @@
+package iso6346
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     Number
+		wantKind ErrorKind
+	}{
+		{
+			"ABCU1234560",
+			Number{"ABC", 'U', 123456, 0},
+			"",
+		},
+		{
+			"abc u 123456 0",
+			Number{"ABC", 'U', 123456, 0},
+			"",
+		},
+		{
+			"CSQ-U-305438-3",
+			Number{"CSQ", 'U', 305438, 3},
+			"",
+		},
+		{
+			"CMAU1639120",
+			Number{"CMA", 'U', 163912, 0},
+			"",
+		},
+		{
+			"ABCU1234561",
+			Number{},
+			ErrorKindCheckDigitMismatch,
+		},
+		{
+			"ABCU123456",
+			Number{},
+			ErrorKindBadLength,
+		},
+		{
+			"AB1U1234560",
+			Number{},
+			ErrorKindBadFormat,
+		},
+		{
+			"ABCU12345A0",
+			Number{},
+			ErrorKindBadFormat,
+		},
+		{
+			"ÄBCU1234560",
+			Number{},
+			ErrorKindBadLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseNumber(tt.in)
+			var gotKind ErrorKind
+			var validateErr *ValidateError
+			if errors.As(err, &validateErr) {
+				gotKind = validateErr.Kind
+			}
+			if gotKind != tt.wantKind {
+				t.Errorf("ParseNumber() error = %v, want kind %v", err, tt.wantKind)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumber_Format(t *testing.T) {
+	tests := []struct {
+		name   string
+		number Number
+		layout Layout
+		want   string
+	}{
+		{
+			"Format without separators",
+			Number{"ABC", 'U', 1234, 5},
+			Layout{},
+			"ABCU0012345",
+		},
+		{
+			"Format with separators",
+			Number{"ABC", 'U', 1234, 5},
+			Layout{SepOE: " ", SepES: "-", SepSC: "/"},
+			"ABC U-001234/5",
+		},
+		{
+			"Format zero value",
+			Number{},
+			Layout{SepOE: " ", SepES: " ", SepSC: " "},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.number.Format(tt.layout); got != tt.want {
+				t.Errorf("Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumber_JSON(t *testing.T) {
+	type model struct {
+		Number   Number `json:"number"`
+		Optional Number `json:"optional"`
+	}
+	in := model{Number: Number{"ABC", 'U', 123456, 0}}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"number":"ABCU1234560","optional":null}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	var got model
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got != in {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, in)
+	}
+
+	if err := json.Unmarshal([]byte(`{"number":"ABCU1234561"}`), &got); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error for invalid check digit")
+	}
+}
+
+func TestNumber_ScanValue(t *testing.T) {
+	want := Number{"ABC", 'U', 123456, 0}
+
+	value, err := want.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	if value != "ABCU1234560" {
+		t.Errorf("Value() = %v, want ABCU1234560", value)
+	}
+
+	for _, src := range []any{"ABCU1234560", []byte("ABC U 123456 0")} {
+		var got Number
+		if err := got.Scan(src); err != nil {
+			t.Errorf("Scan(%v) error = %v", src, err)
+		}
+		if got != want {
+			t.Errorf("Scan(%v) = %v, want %v", src, got, want)
+		}
+	}
+
+	got := want
+	if err := got.Scan(nil); err != nil || !got.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v, want zero value", got, err)
+	}
+	if value, err := got.Value(); value != nil || err != nil {
+		t.Errorf("Value() = %v, %v, want nil", value, err)
+	}
+	if err := got.Scan(42); err == nil {
+		t.Errorf("Scan(42) error = nil, want error")
+	}
+}
+
+func TestValidateCheckDigit(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   Number
+		strict   bool
+		wantKind ErrorKind
+		wantExp  string
+	}{
+		{
+			"Valid check digit",
+			Number{"ABC", 'U', 123456, 0},
+			true,
+			"",
+			"",
+		},
+		{
+			"Check digit mismatch",
+			Number{"ABC", 'U', 123123, 1},
+			false,
+			ErrorKindCheckDigitMismatch,
+			"7",
+		},
+		{
+			"Check digit 10 is valid",
+			Number{"CMA", 'U', 163912, 0},
+			false,
+			"",
+			"",
+		},
+		{
+			"Check digit 10 is invalid in strict mode",
+			Number{"CMA", 'U', 163912, 0},
+			true,
+			ErrorKindCheckDigit10,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCheckDigit(tt.number, tt.strict)
+			if tt.wantKind == "" {
+				if err != nil {
+					t.Errorf("ValidateCheckDigit() error = %v, want nil", err)
+				}
+				return
+			}
+			var validateErr *ValidateError
+			if !errors.As(err, &validateErr) {
+				t.Fatalf("ValidateCheckDigit() error = %v, want ValidateError", err)
+			}
+			if validateErr.Kind != tt.wantKind || validateErr.Expected != tt.wantExp ||
+				validateErr.Field != FieldCheckDigit || validateErr.Offset != 10 {
+				t.Errorf("ValidateCheckDigit() error = %+v, want kind %v and expected %v", validateErr, tt.wantKind, tt.wantExp)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package iso6346
+
+import (
+	"strings"
+	"unicode"
+)
+
+// OCRSubstitution is a character that was replaced by CorrectOCR.
+// Pos is the position in the container number from 0 (first letter of owner code) to 10 (check digit).
+type OCRSubstitution struct {
+	Pos  int
+	From rune
+	To   rune
+}
+
+// ocrLetters maps digits to letters that are commonly confused by OCR.
+var ocrLetters = map[rune]rune{
+	'0': 'O',
+	'1': 'I',
+	'2': 'Z',
+	'5': 'S',
+	'8': 'B',
+}
+
+// ocrDigits maps letters to digits that are commonly confused by OCR.
+var ocrDigits = map[rune]rune{
+	'B': '8',
+	'I': '1',
+	'O': '0',
+	'Q': '0',
+	'S': '5',
+	'Z': '2',
+}
+
+// CorrectOCR replaces characters of s that are commonly confused by OCR like O and 0.
+// The first 11 ASCII letters and digits of s are the container number. Owner code and
+// equipment category ID must be letters, serial number and check digit must be digits.
+// All other characters of s are kept.
+// ok is only true if at least one character was replaced and the check digit of the
+// corrected container number is valid.
+func CorrectOCR(s string) (corrected string, substitutions []OCRSubstitution, ok bool) {
+	b := strings.Builder{}
+	pos := 0
+	for _, r := range s {
+		if pos > 10 || !isASCIIAlphanumeric(r) {
+			b.WriteRune(r)
+			continue
+		}
+		mapping := ocrDigits
+		if pos < 4 {
+			mapping = ocrLetters
+		}
+		if to, found := mapping[unicode.ToUpper(r)]; found {
+			substitutions = append(substitutions, OCRSubstitution{Pos: pos, From: r, To: to})
+			r = to
+		}
+		b.WriteRune(r)
+		pos++
+	}
+	if substitutions == nil {
+		return s, nil, false
+	}
+	corrected = b.String()
+	if _, err := ParseNumber(firstAlphanumeric(corrected, 11)); err != nil {
+		return s, nil, false
+	}
+	return corrected, substitutions, true
+}
+
+func isASCIIAlphanumeric(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+func firstAlphanumeric(s string, count int) string {
+	b := strings.Builder{}
+	for _, r := range s {
+		if b.Len() == count {
+			break
+		}
+		if isASCIIAlphanumeric(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package iso6346
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCorrectOCR(t *testing.T) {
+	tests := []struct {
+		name              string
+		s                 string
+		wantCorrected     string
+		wantSubstitutions []OCRSubstitution
+		wantOk            bool
+	}{
+		{
+			"Letters and digits confused",
+			"A8C U 68I3O4 O",
+			"ABC U 681304 0",
+			[]OCRSubstitution{
+				{1, '8', 'B'},
+				{6, 'I', '1'},
+				{8, 'O', '0'},
+				{10, 'O', '0'},
+			},
+			true,
+		},
+		{
+			"Not confusable character",
+			"abc u 68l3o4 0 20G1",
+			"abc u 68l3o4 0 20G1",
+			nil,
+			false,
+		},
+		{
+			"Lower case letter confused and size type kept",
+			"abc u 6813o4 0 20G1",
+			"abc u 681304 0 20G1",
+			[]OCRSubstitution{{8, 'o', '0'}},
+			true,
+		},
+		{
+			"Check digit not valid after correction",
+			"A8C U 681304 1",
+			"A8C U 681304 1",
+			nil,
+			false,
+		},
+		{
+			"Nothing to correct",
+			"ABC U 681304 0",
+			"ABC U 681304 0",
+			nil,
+			false,
+		},
+		{
+			"Too short",
+			"2OG1",
+			"2OG1",
+			nil,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCorrected, gotSubstitutions, gotOk := CorrectOCR(tt.s)
+			if gotCorrected != tt.wantCorrected {
+				t.Errorf("CorrectOCR() gotCorrected = %v, want %v", gotCorrected, tt.wantCorrected)
+			}
+			if !slices.Equal(gotSubstitutions, tt.wantSubstitutions) {
+				t.Errorf("CorrectOCR() gotSubstitutions = %v, want %v", gotSubstitutions, tt.wantSubstitutions)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("CorrectOCR() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
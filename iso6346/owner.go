@@ -0,0 +1,36 @@
+package iso6346
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Owner has a code and associated company with its location in the form of country and city.
+type Owner struct {
+	Code    string
+	Company string
+	City    string
+	Country string
+	// EquipCatIDs are the registered equipment category IDs of the owner code, e.g. UJ.
+	// It is empty if the registered equipment category IDs are unknown.
+	EquipCatIDs string
+}
+
+// IsEquipCatIDRegistered returns true if the equipment category ID is registered for the owner
+// or if the registered equipment category IDs are unknown.
+func (o Owner) IsEquipCatIDRegistered(ID string) bool {
+	return o.EquipCatIDs == "" || (len(ID) == 1 && strings.Contains(o.EquipCatIDs, ID))
+}
+
+// IsOwnerCode checks if string is three upper case letters.
+func IsOwnerCode(code string) error {
+	if len(code) != 3 {
+		return NewFieldError(ErrorKindBadLength, FieldOwnerCode, "3",
+			fmt.Sprintf("%s is not 3 letters long", code))
+	}
+	if !isUpperLetter(code) {
+		return NewFieldError(ErrorKindBadFormat, FieldOwnerCode, "",
+			fmt.Sprintf("%s is not 3 upper case letters", code))
+	}
+	return nil
+}
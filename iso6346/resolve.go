@@ -0,0 +1,196 @@
+package iso6346
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxCombinations limits the combinations of alternatives that Resolve enumerates.
+const maxCombinations = 10_000_000
+
+// Alternative is a candidate character of one position of a container number
+// with the confidence of an OCR engine from 0 to 1.
+type Alternative struct {
+	Char       rune
+	Confidence float64
+}
+
+// Candidate is a container number resolved from alternatives.
+// Confidence is the product of the confidences of the used alternatives.
+type Candidate struct {
+	Number
+	Confidence float64
+}
+
+// ParseAlternatives parses a container number with alternatives per position like
+// "ABC U 12[3:0.6,8:0.4]456 0". The characters in brackets are the alternatives of one
+// position with an optional confidence. Characters without confidence have confidence 1.
+// Every character outside brackets that is not an ASCII letter or digit is treated as a separator.
+// ParseAlternatives returns an error if the syntax is invalid or there are not 11 positions.
+func ParseAlternatives(s string) ([][]Alternative, error) {
+	var positions [][]Alternative
+	for len(s) > 0 {
+		r := rune(s[0])
+		switch {
+		case r == '[':
+			end := strings.IndexRune(s, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("%s has no closing bracket", s)
+			}
+			alternatives, err := parseAlternativeList(s[1:end])
+			if err != nil {
+				return nil, err
+			}
+			positions = append(positions, alternatives)
+			s = s[end+1:]
+			continue
+		case r == ']':
+			return nil, errors.New("closing bracket without opening bracket")
+		case isASCIIAlphanumeric(r):
+			positions = append(positions, []Alternative{{unicode.ToUpper(r), 1}})
+		}
+		s = s[1:]
+	}
+	if len(positions) != 11 {
+		return nil, NewFieldError(ErrorKindBadLength, "", "11",
+			fmt.Sprintf("%d positions are not 11 positions", len(positions)))
+	}
+	return positions, nil
+}
+
+func parseAlternativeList(s string) ([]Alternative, error) {
+	var alternatives []Alternative
+	for _, entry := range strings.Split(s, ",") {
+		char, confidence, hasConfidence := strings.Cut(strings.TrimSpace(entry), ":")
+		if len(char) != 1 || !isASCIIAlphanumeric(rune(char[0])) {
+			return nil, fmt.Errorf("%s is not 1 letter or digit", char)
+		}
+		alternative := Alternative{unicode.ToUpper(rune(char[0])), 1}
+		if hasConfidence {
+			value, err := strconv.ParseFloat(confidence, 64)
+			if err != nil || value < 0 || value > 1 {
+				return nil, fmt.Errorf("confidence %s of %s is not a number from 0 to 1", confidence, char)
+			}
+			alternative.Confidence = value
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	return alternatives, nil
+}
+
+// Resolve returns the container numbers of all combinations of alternatives with a
+// valid check digit and an owner code that isRegistered. If isRegistered is nil every
+// owner code is accepted. Alternatives that do not fit the position are skipped,
+// e.g. a digit in the owner code, and duplicate alternatives of a position count once
+// with the highest confidence. The result is ordered by confidence descending.
+// Resolve returns an error if there are not 11 positions or too many combinations.
+func Resolve(positions [][]Alternative, isRegistered func(ownerCode string) bool) ([]Candidate, error) {
+	if len(positions) != 11 {
+		return nil, NewFieldError(ErrorKindBadLength, "", "11",
+			fmt.Sprintf("%d positions are not 11 positions", len(positions)))
+	}
+
+	fitting := make([][]Alternative, len(positions))
+	combinations := 1
+	for i, alternatives := range positions {
+		for _, alternative := range alternatives {
+			if !fitsPosition(i, alternative.Char) {
+				continue
+			}
+			idx := slices.IndexFunc(fitting[i], func(a Alternative) bool { return a.Char == alternative.Char })
+			if idx == -1 {
+				fitting[i] = append(fitting[i], alternative)
+				continue
+			}
+			fitting[i][idx].Confidence = max(fitting[i][idx].Confidence, alternative.Confidence)
+		}
+		combinations *= max(len(fitting[i]), 1)
+		if combinations > maxCombinations {
+			return nil, fmt.Errorf("alternatives have more than %d combinations", maxCombinations)
+		}
+	}
+
+	// Prefixes are pruned by registered owner codes before the serial numbers are combined.
+	type prefix struct {
+		chars      [4]byte
+		confidence float64
+	}
+	prefixes := []prefix{{confidence: 1}}
+	for i, alternatives := range fitting[:4] {
+		next := make([]prefix, 0, len(prefixes)*len(alternatives))
+		for _, p := range prefixes {
+			for _, alternative := range alternatives {
+				p.chars[i] = byte(alternative.Char)
+				next = append(next, prefix{p.chars, p.confidence * alternative.Confidence})
+			}
+		}
+		prefixes = next
+	}
+	prefixes = slices.DeleteFunc(prefixes, func(p prefix) bool {
+		return isRegistered != nil && !isRegistered(string(p.chars[:3]))
+	})
+
+	// Serial numbers are combined as numbers, e.g. 12 of the alternatives 1 and 2.
+	type serial struct {
+		num        int
+		confidence float64
+	}
+	serialNums := []serial{{0, 1}}
+	for _, alternatives := range fitting[4:10] {
+		next := make([]serial, 0, len(serialNums)*len(alternatives))
+		for _, s := range serialNums {
+			for _, alternative := range alternatives {
+				next = append(next, serial{s.num*10 + int(alternative.Char-'0'), s.confidence * alternative.Confidence})
+			}
+		}
+		serialNums = next
+	}
+
+	// The check digit is calculated instead of combined.
+	var checkDigitConfidences [10]float64
+	var isCheckDigit [10]bool
+	for _, alternative := range fitting[10] {
+		checkDigitConfidences[alternative.Char-'0'] = alternative.Confidence
+		isCheckDigit[alternative.Char-'0'] = true
+	}
+
+	var candidates []Candidate
+	for _, p := range prefixes {
+		ownerCode := string(p.chars[:3])
+		equipCatID := rune(p.chars[3])
+		for _, s := range serialNums {
+			checkDigit := CalcCheckDigit(ownerCode, equipCatID, s.num) % 10
+			if !isCheckDigit[checkDigit] {
+				continue
+			}
+			candidates = append(candidates, Candidate{
+				Number:     Number{ownerCode, equipCatID, s.num, checkDigit},
+				Confidence: p.confidence * s.confidence * checkDigitConfidences[checkDigit],
+			})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		if c := cmp.Compare(b.Confidence, a.Confidence); c != 0 {
+			return c
+		}
+		return strings.Compare(a.String(), b.String())
+	})
+	return candidates, nil
+}
+
+func fitsPosition(pos int, char rune) bool {
+	switch {
+	case pos < 3:
+		return char >= 'A' && char <= 'Z'
+	case pos == 3:
+		return IsEquipCatID(string(char)) == nil
+	default:
+		return char >= '0' && char <= '9'
+	}
+}
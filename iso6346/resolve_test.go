@@ -0,0 +1,147 @@
+package iso6346
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAlternatives(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    [][]Alternative
+		wantErr bool
+	}{
+		{
+			"Alternatives with and without confidence",
+			"abc U 12[3:0.6, 8:0.4]456 [0,6]",
+			[][]Alternative{
+				{{'A', 1}}, {{'B', 1}}, {{'C', 1}}, {{'U', 1}},
+				{{'1', 1}}, {{'2', 1}}, {{'3', 0.6}, {'8', 0.4}}, {{'4', 1}}, {{'5', 1}}, {{'6', 1}},
+				{{'0', 1}, {'6', 1}},
+			},
+			false,
+		},
+		{
+			"Missing closing bracket",
+			"ABC U 12[3:0.6,8:0.4456 0",
+			nil,
+			true,
+		},
+		{
+			"Closing bracket without opening bracket",
+			"ABC U 123]456 0",
+			nil,
+			true,
+		},
+		{
+			"Confidence out of range",
+			"ABC U 12[3:1.6]456 0",
+			nil,
+			true,
+		},
+		{
+			"Alternative with multiple characters",
+			"ABC U 12[38]456 0",
+			nil,
+			true,
+		},
+		{
+			"Too few positions",
+			"ABC U 12[3,8]456",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAlternatives(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAlternatives() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAlternatives() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	isABC := func(ownerCode string) bool {
+		return ownerCode == "ABC"
+	}
+	tests := []struct {
+		name         string
+		s            string
+		isRegistered func(ownerCode string) bool
+		want         []Candidate
+	}{
+		{
+			"Ordered by confidence",
+			"ABC U 68[1:0.3,7:0.7]304 [0:0.5,6:0.5]",
+			nil,
+			[]Candidate{
+				{Number{"ABC", 'U', 687304, 0}, 0.35},
+				{Number{"ABC", 'U', 681304, 0}, 0.15},
+			},
+		},
+		{
+			"Only registered owner codes",
+			"A[B:0.4,8:0.6]C U 681304 0",
+			isABC,
+			[]Candidate{
+				{Number{"ABC", 'U', 681304, 0}, 0.4},
+			},
+		},
+		{
+			"Not registered owner code",
+			"A[X:0.4,8:0.6]C U 681304 0",
+			isABC,
+			nil,
+		},
+		{
+			"Duplicate alternatives with highest confidence",
+			"ABC U 68[1:0.3,7:0.7,1:0.5]304 [0:0.5,6:0.5,0:0.2]",
+			nil,
+			[]Candidate{
+				{Number{"ABC", 'U', 687304, 0}, 0.35},
+				{Number{"ABC", 'U', 681304, 0}, 0.25},
+			},
+		},
+		{
+			"No valid check digit",
+			"ABC U 681304 [1,2]",
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions, err := ParseAlternatives(tt.s)
+			if err != nil {
+				t.Fatalf("ParseAlternatives() error = %v", err)
+			}
+			got, err := Resolve(positions, tt.isRegistered)
+			if err != nil {
+				t.Errorf("Resolve() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTooManyCombinations(t *testing.T) {
+	digits := []Alternative{{'0', 1}, {'1', 1}, {'2', 1}, {'3', 1}, {'4', 1}, {'5', 1}, {'6', 1}, {'7', 1}, {'8', 1}, {'9', 1}}
+	positions := [][]Alternative{{{'A', 1}}, {{'B', 1}}, {{'C', 1}}, {{'U', 1}}}
+	for range 7 {
+		positions = append(positions, digits)
+	}
+	positions[0] = append(positions[0], Alternative{'X', 1})
+	if _, err := Resolve(positions, nil); err == nil {
+		t.Errorf("Resolve() error = nil, want error")
+	}
+}
@@ -0,0 +1,75 @@
+package iso6346
+
+import "fmt"
+
+// Width describes width of first code in the specified standard size code.
+type Width string
+
+// Height describes height of first code in the specified standard size code.
+type Height string
+
+// Length describes length of second code in the specified standard size code.
+type Length string
+
+// TypeInfo has information about the specified standard type.
+type TypeInfo string
+
+// GroupInfo has information about the specified type group.
+type GroupInfo string
+
+// TypeGroupInfo has information about the specified type group code, e.g. GP.
+type TypeGroupInfo string
+
+// IsLengthCode returns nil if input is one upper case alphanumeric character.
+func IsLengthCode(code string) error {
+	return isOneUpperAlphanumericChar(FieldLengthCode, code)
+}
+
+// IsHeightWidthCode returns nil if input is one upper case alphanumeric character.
+func IsHeightWidthCode(code string) error {
+	return isOneUpperAlphanumericChar(FieldHeightWidthCode, code)
+}
+
+// IsTypeCode returns nil if input is two upper case alphanumeric characters.
+func IsTypeCode(code string) error {
+	if len(code) != 2 {
+		return NewFieldError(ErrorKindBadLength, FieldTypeCode, "2",
+			fmt.Sprintf("%s is not 2 characters long", code))
+	}
+	if !isUpperAlphanumeric(code) {
+		return NewFieldError(ErrorKindBadFormat, FieldTypeCode, "",
+			fmt.Sprintf("%s is not 2 upper case alphanumeric characters", code))
+	}
+	return nil
+}
+
+// IsTypeGroupCode returns nil if input is two upper case letters like the type
+// group codes GP, RE or TN that group detailed type codes.
+func IsTypeGroupCode(code string) error {
+	if len(code) != 2 {
+		return NewFieldError(ErrorKindBadLength, FieldTypeCode, "2",
+			fmt.Sprintf("%s is not 2 characters long", code))
+	}
+	if !isUpperLetter(code) {
+		return NewFieldError(ErrorKindBadFormat, FieldTypeCode, "",
+			fmt.Sprintf("%s is not 2 upper case letters", code))
+	}
+	return nil
+}
+
+// IsLegacySizeTypeCode returns nil if input is four digits like the size and type
+// codes of ISO 6346:1984, e.g. 2210. The first two digits are the size and the
+// last two digits are the type.
+func IsLegacySizeTypeCode(code string) error {
+	if len(code) != 4 {
+		return NewFieldError(ErrorKindBadLength, FieldLegacySizeTypeCode, "4",
+			fmt.Sprintf("%s is not 4 digits long", code))
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return NewFieldError(ErrorKindBadFormat, FieldLegacySizeTypeCode, "",
+				fmt.Sprintf("%s is not 4 digits", code))
+		}
+	}
+	return nil
+}
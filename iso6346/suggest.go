@@ -0,0 +1,96 @@
+package iso6346
+
+import (
+	"slices"
+	"strings"
+)
+
+// SuggestOwnerCodes returns owner codes of ownerCodes with an edit distance
+// of at most maxDistance to code. Swapped adjacent letters count as one edit.
+// The result is ordered by edit distance and owner code.
+func SuggestOwnerCodes(code string, ownerCodes []string, maxDistance int) []string {
+	type candidate struct {
+		code     string
+		distance int
+	}
+	var candidates []candidate
+	for _, ownerCode := range ownerCodes {
+		distance := editDistance(code, ownerCode)
+		if distance > 0 && distance <= maxDistance {
+			candidates = append(candidates, candidate{ownerCode, distance})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.code, b.code)
+	})
+
+	var codes []string
+	for _, c := range candidates {
+		codes = append(codes, c.code)
+	}
+	return codes
+}
+
+// editDistance returns the optimal string alignment distance of a and b.
+// It is the Levenshtein distance with transposition of adjacent characters as additional edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// SuggestCorrections returns valid container numbers that differ from n by
+// one keying error of the serial number or check digit.
+// The suggestions are ranked by likelihood: the corrected check digit first, then
+// substitutions, transpositions, jump transpositions and twin errors.
+// SuggestCorrections returns nil if the check digit of n is valid.
+func SuggestCorrections(n Number) []ErrorProneNumber {
+	if ValidateCheckDigit(n, false) == nil {
+		return nil
+	}
+	calc := CalcCheckDigit(n.OwnerCode, n.EquipCatID, n.SerialNumber) % 10
+	suggestions := []ErrorProneNumber{
+		{Number{n.OwnerCode, n.EquipCatID, n.SerialNumber, calc}, ErrorClassSubstitution, 6},
+	}
+
+	numbers := CheckErrorProne(n.OwnerCode, n.EquipCatID, n.SerialNumber, n.CheckDigit)
+	slices.SortStableFunc(numbers, func(a, b ErrorProneNumber) int {
+		return classRank(a.Class) - classRank(b.Class)
+	})
+	return append(suggestions, numbers...)
+}
+
+func classRank(class ErrorClass) int {
+	switch class {
+	case ErrorClassSubstitution:
+		return 0
+	case ErrorClassTransposition:
+		return 1
+	case ErrorClassJumpTransposition:
+		return 2
+	default:
+		return 3
+	}
+}
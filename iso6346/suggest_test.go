@@ -0,0 +1,104 @@
+package iso6346
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSuggestOwnerCodes(t *testing.T) {
+	ownerCodes := []string{"ABD", "BAC", "XYZ", "ABC", "AXY", "CBA"}
+	tests := []struct {
+		name        string
+		code        string
+		maxDistance int
+		want        []string
+	}{
+		{
+			"Substitution and transposition",
+			"ABC",
+			1,
+			[]string{"ABD", "BAC"},
+		},
+		{
+			"Ordered by distance",
+			"ABC",
+			2,
+			[]string{"ABD", "BAC", "AXY", "CBA"},
+		},
+		{
+			"No suggestion",
+			"QQQ",
+			1,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestOwnerCodes(tt.code, ownerCodes, tt.maxDistance); !slices.Equal(got, tt.want) {
+				t.Errorf("SuggestOwnerCodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"ABC", "ABC", 0},
+		{"ABC", "ABD", 1},
+		{"ABC", "BAC", 1},
+		{"ABC", "CBA", 2},
+		{"ABC", "XYZ", 3},
+		{"AB", "ABC", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestCorrections(t *testing.T) {
+	tests := []struct {
+		name   string
+		number Number
+		want   []ErrorProneNumber
+	}{
+		{
+			"Valid container number",
+			Number{"ABC", 'U', 123456, 0},
+			nil,
+		},
+		{
+			"Wrong check digit",
+			Number{"ABC", 'U', 123123, 1},
+			[]ErrorProneNumber{
+				{Number{"ABC", 'U', 123123, 7}, ErrorClassSubstitution, 6},
+				{Number{"ABC", 'U', 223123, 1}, ErrorClassSubstitution, 0},
+				{Number{"ABC", 'U', 183123, 1}, ErrorClassSubstitution, 1},
+				{Number{"ABC", 'U', 126123, 1}, ErrorClassSubstitution, 2},
+				{Number{"ABC", 'U', 123823, 1}, ErrorClassSubstitution, 3},
+				{Number{"ABC", 'U', 123103, 1}, ErrorClassSubstitution, 4},
+				{Number{"ABC", 'U', 123122, 1}, ErrorClassSubstitution, 5},
+				{Number{"ABC", 'U', 122133, 1}, ErrorClassJumpTransposition, 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestCorrections(tt.number)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SuggestCorrections() = %v, want %v", got, tt.want)
+			}
+			for _, suggestion := range got {
+				if err := ValidateCheckDigit(suggestion.Number, false); err != nil {
+					t.Errorf("ValidateCheckDigit() of %v = %v", suggestion.Number, err)
+				}
+			}
+		})
+	}
+}
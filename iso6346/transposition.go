@@ -1,4 +1,4 @@
-package cont
+package iso6346
 
 import "math"
 
@@ -13,6 +13,7 @@ type TpNumber struct {
 // CheckTransposition returns an array of TpNumber's for error-prone serial numbers.
 // CheckTransposition returns nil if no error-prone serial number is found.
 // Not equal adjacent digits including check digit are transposed and checked.
+// See CheckErrorProne for more classes of keying errors.
 func CheckTransposition(ownerCode string, equipCatID rune, serialNum int, checkDigit int) []TpNumber {
 	checkDigit = checkDigit % 10
 
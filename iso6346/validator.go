@@ -0,0 +1,137 @@
+package iso6346
+
+import "fmt"
+
+// ErrorKind is the machine-readable kind of a ValidateError.
+type ErrorKind string
+
+// Kinds of a ValidateError.
+const (
+	ErrorKindBadLength                 ErrorKind = "bad-length"
+	ErrorKindBadFormat                 ErrorKind = "bad-format"
+	ErrorKindOwnerNotRegistered        ErrorKind = "owner-not-registered"
+	ErrorKindUnknownEquipCatID         ErrorKind = "unknown-equipment-category-id"
+	ErrorKindEquipCatIDNotRegistered   ErrorKind = "equipment-category-id-not-registered"
+	ErrorKindCheckDigitNotCalculable   ErrorKind = "check-digit-not-calculable"
+	ErrorKindCheckDigitMismatch        ErrorKind = "check-digit-mismatch"
+	ErrorKindCheckDigit10              ErrorKind = "check-digit-10"
+	ErrorKindUnknownLengthCode         ErrorKind = "unknown-length-code"
+	ErrorKindUnknownHeightWidthCode    ErrorKind = "unknown-height-width-code"
+	ErrorKindUnknownTypeCode           ErrorKind = "unknown-type-code"
+	ErrorKindUnknownCountryCode        ErrorKind = "unknown-country-code"
+	ErrorKindUnknownLegacySizeTypeCode ErrorKind = "unknown-legacy-size-type-code"
+	ErrorKindUnknown                   ErrorKind = "unknown"
+)
+
+// Field is a part of a container marking.
+type Field string
+
+// Fields of a container marking.
+const (
+	FieldOwnerCode          Field = "owner-code"
+	FieldEquipCatID         Field = "equipment-category-id"
+	FieldSerialNumber       Field = "serial-number"
+	FieldCheckDigit         Field = "check-digit"
+	FieldLengthCode         Field = "length-code"
+	FieldHeightWidthCode    Field = "height-width-code"
+	FieldTypeCode           Field = "type-code"
+	FieldCountryCode        Field = "country-code"
+	FieldLegacySizeTypeCode Field = "legacy-size-type-code"
+)
+
+// Offset returns the character offset of the field in a container marking
+// without separators and legacy country code, e.g. 4 for the serial number
+// in ABCU1234560 22G1. An unknown field returns 0.
+func (f Field) Offset() int {
+	switch f {
+	case FieldEquipCatID:
+		return 3
+	case FieldSerialNumber:
+		return 4
+	case FieldCheckDigit:
+		return 10
+	case FieldCountryCode:
+		return 11
+	case FieldLengthCode, FieldLegacySizeTypeCode:
+		return 11
+	case FieldHeightWidthCode:
+		return 12
+	case FieldTypeCode:
+		return 13
+	default:
+		return 0
+	}
+}
+
+// ValidateError is an error for validation of a container number part.
+type ValidateError struct {
+	// Kind is the machine-readable kind of the error.
+	Kind ErrorKind
+	// Field is the offending part of the container marking.
+	Field Field
+	// Offset is the character offset of Field in a container marking without separators.
+	Offset int
+	// Expected is the expected value, e.g. the calculated check digit. It is empty if unknown.
+	Expected string
+	message  string
+}
+
+// NewValidateError returns a new ValidateError of kind ErrorKindUnknown.
+func NewValidateError(message string) error {
+	return &ValidateError{
+		Kind:    ErrorKindUnknown,
+		message: message,
+	}
+}
+
+// NewFieldError returns a new ValidateError of kind for field.
+// The offset is set to the offset of field.
+func NewFieldError(kind ErrorKind, field Field, expected string, message string) *ValidateError {
+	return &ValidateError{
+		Kind:     kind,
+		Field:    field,
+		Offset:   field.Offset(),
+		Expected: expected,
+		message:  message,
+	}
+}
+
+func (e *ValidateError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	if e.Expected != "" {
+		return fmt.Sprintf("%s: %s (expected %s)", e.Field, e.Kind, e.Expected)
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Kind)
+}
+
+func isOneUpperAlphanumericChar(field Field, code string) error {
+	if len(code) != 1 {
+		return NewFieldError(ErrorKindBadLength, field, "1",
+			fmt.Sprintf("%s is not 1 digit", code))
+	}
+	if !isUpperAlphanumeric(code) {
+		return NewFieldError(ErrorKindBadFormat, field, "",
+			fmt.Sprintf("%s is not 1 upper case alphanumeric character", code))
+	}
+	return nil
+}
+
+func isUpperAlphanumeric(s string) bool {
+	for _, r := range s {
+		if !isUpperLetter(string(r)) && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperLetter(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}